@@ -0,0 +1,376 @@
+package main
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/db/migrate"
+	"backend-test/internal/entity"
+)
+
+const _exportBatchSize = 500
+
+//go:embed fixtures/businesses.json
+var _fixtures []byte
+
+// businessRecord is the import/export format. It is entity.Business plus the
+// opening hours, which the entity hides from API responses.
+type businessRecord struct {
+	entity.Business
+	OpenTime  datatypes.Time `json:"open_time"`
+	CloseTime datatypes.Time `json:"close_time"`
+}
+
+func (r businessRecord) entity() entity.Business {
+	b := r.Business
+	b.OpenTime = r.OpenTime
+	b.CloseTime = r.CloseTime
+	return b
+}
+
+func migrateCmd(e *env, args []string) (interface{}, string, error) {
+	if len(args) < 1 {
+		return nil, "", errors.New("migrate: expected up, down or status")
+	}
+
+	db, err := e.openDB()
+	if err != nil {
+		return nil, "", err
+	}
+
+	m, err := migrate.New(db)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch args[0] {
+	case "up":
+		done, err := m.Up(e.ctx)
+		return migrationsResult(done), migrationsSummary("applied", done), err
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := fs.Int("steps", 1, "number of migrations to revert")
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, "", err
+		}
+
+		done, err := m.Down(e.ctx, *steps)
+		return migrationsResult(done), migrationsSummary("reverted", done), err
+	case "status":
+		statuses, err := m.Status(e.ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var sb strings.Builder
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(&sb, "%06d  %-30s %s\n", s.Version, s.Name, appliedAt)
+		}
+
+		return statuses, strings.TrimSuffix(sb.String(), "\n"), nil
+	default:
+		return nil, "", fmt.Errorf("migrate: unknown subcommand %q", args[0])
+	}
+}
+
+func migrationsResult(done []migrate.Migration) []string {
+	names := make([]string, 0, len(done))
+	for _, mg := range done {
+		names = append(names, fmt.Sprintf("%06d_%s", mg.Version, mg.Name))
+	}
+	return names
+}
+
+func migrationsSummary(verb string, done []migrate.Migration) string {
+	if len(done) == 0 {
+		return "no migrations " + verb
+	}
+	return verb + " " + strings.Join(migrationsResult(done), ", ")
+}
+
+type importResult struct {
+	Created int      `json:"created"`
+	Skipped int      `json:"skipped"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+func seedCmd(e *env, args []string) (interface{}, string, error) {
+	var records []businessRecord
+	if err := json.Unmarshal(_fixtures, &records); err != nil {
+		return nil, "", fmt.Errorf("seed - decode fixtures: %w", err)
+	}
+
+	res, err := importRecords(e, records, true)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res, importSummary(res), nil
+}
+
+func importCmd(e *env, args []string) (interface{}, string, error) {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "JSON file produced by export")
+	skipExisting := fs.Bool("skip-existing", false, "skip businesses whose id already exists")
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+
+	if *file == "" {
+		return nil, "", errors.New("import: -file is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return nil, "", fmt.Errorf("import - read: %w", err)
+	}
+
+	var records []businessRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, "", fmt.Errorf("import - decode: %w", err)
+	}
+
+	res, err := importRecords(e, records, *skipExisting)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if res.Failed > 0 {
+		return res, importSummary(res), fmt.Errorf("import: %d businesses failed", res.Failed)
+	}
+
+	return res, importSummary(res), nil
+}
+
+func importRecords(e *env, records []businessRecord, skipExisting bool) (importResult, error) {
+	var res importResult
+
+	br, err := e.businessRepo()
+	if err != nil {
+		return res, err
+	}
+
+	for _, r := range records {
+		b := r.entity()
+		b.UUID = 0
+		if b.ID == "" {
+			b.ID = generateID()
+		}
+
+		if skipExisting {
+			_, err := br.ReadById(e.ctx, b.ID)
+			if err == nil {
+				res.Skipped++
+				continue
+			}
+			if !errors.Is(err, entity.ErrNotFound) {
+				return res, fmt.Errorf("import - repo.ReadById %s: %w", b.ID, err)
+			}
+		}
+
+		if err := br.Create(e.ctx, b); err != nil {
+			res.Failed++
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %s", b.ID, err))
+			continue
+		}
+		res.Created++
+	}
+
+	return res, nil
+}
+
+func importSummary(res importResult) string {
+	summary := fmt.Sprintf("created %d, skipped %d, failed %d", res.Created, res.Skipped, res.Failed)
+	for _, err := range res.Errors {
+		summary += "\n  " + err
+	}
+	return summary
+}
+
+type exportResult struct {
+	Count      int              `json:"count"`
+	File       string           `json:"file,omitempty"`
+	Businesses []businessRecord `json:"businesses,omitempty"`
+}
+
+func exportCmd(e *env, args []string) (interface{}, string, error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+
+	br, err := e.businessRepo()
+	if err != nil {
+		return nil, "", err
+	}
+
+	records := []businessRecord{}
+	for offset := 0; ; offset += _exportBatchSize {
+		batch, err := br.List(e.ctx, _exportBatchSize, offset)
+		if err != nil {
+			return nil, "", fmt.Errorf("export - repo.List: %w", err)
+		}
+
+		for _, b := range batch {
+			records = append(records, businessRecord{Business: b, OpenTime: b.OpenTime, CloseTime: b.CloseTime})
+		}
+
+		if len(batch) < _exportBatchSize {
+			break
+		}
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return nil, "", fmt.Errorf("export - encode: %w", err)
+	}
+
+	if *file == "" {
+		return exportResult{Count: len(records), Businesses: records}, string(data), nil
+	}
+
+	if err := os.WriteFile(*file, data, 0o644); err != nil {
+		return nil, "", fmt.Errorf("export - write: %w", err)
+	}
+
+	return exportResult{Count: len(records), File: *file}, fmt.Sprintf("exported %d businesses to %s", len(records), *file), nil
+}
+
+type countResult struct {
+	Count int64 `json:"count"`
+}
+
+func purgeCmd(e *env, args []string) (interface{}, string, error) {
+	fs := flag.NewFlagSet("purge", flag.ContinueOnError)
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "only purge rows deleted longer ago than this")
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+
+	br, err := e.businessRepo()
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, err := br.PurgeDeleted(e.ctx, time.Now().Add(-*olderThan))
+	if err != nil {
+		return nil, "", fmt.Errorf("purge - repo.PurgeDeleted: %w", err)
+	}
+
+	return countResult{n}, fmt.Sprintf("purged %d businesses", n), nil
+}
+
+func normalizeRatingsCmd(e *env, args []string) (interface{}, string, error) {
+	br, err := e.businessRepo()
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, err := br.NormalizeRatings(e.ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("normalize-ratings - repo.NormalizeRatings: %w", err)
+	}
+
+	return countResult{n}, fmt.Sprintf("normalized %d ratings", n), nil
+}
+
+func apiKeyCmd(e *env, args []string) (interface{}, string, error) {
+	if len(args) < 1 {
+		return nil, "", errors.New("apikey: expected issue, list or revoke")
+	}
+
+	conn, err := e.openDB()
+	if err != nil {
+		return nil, "", err
+	}
+	keys := usecase.NewAPIKeyUseCase(repo.NewAPIKeyRepo(conn), e.l)
+
+	switch args[0] {
+	case "issue":
+		fs := flag.NewFlagSet("apikey issue", flag.ContinueOnError)
+		name := fs.String("name", "", "who or what the key is for")
+		scopes := fs.String("scopes", entity.ScopeRead, "comma-separated scopes: read, write, admin")
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, "", err
+		}
+
+		k, plain, err := keys.Issue(e.ctx, *name, strings.Split(*scopes, ","))
+		if err != nil {
+			return nil, "", err
+		}
+
+		result := struct {
+			entity.APIKey
+			Key string `json:"key"`
+		}{k, plain}
+		return result, fmt.Sprintf("issued API key %d (%s), store it now, it is not shown again:\n%s", k.ID, k.Scopes, plain), nil
+	case "list":
+		list, err := keys.List(e.ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var sb strings.Builder
+		for _, k := range list {
+			status := "active"
+			if k.Revoked() {
+				status = "revoked " + k.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(&sb, "%4d  %-14s %-20s %-18s %s\n", k.ID, k.Prefix, k.Name, k.Scopes, status)
+		}
+
+		return list, strings.TrimSuffix(sb.String(), "\n"), nil
+	case "revoke":
+		fs := flag.NewFlagSet("apikey revoke", flag.ContinueOnError)
+		id := fs.Uint("id", 0, "id of the key to revoke")
+		if err := fs.Parse(args[1:]); err != nil {
+			return nil, "", err
+		}
+
+		if err := keys.Revoke(e.ctx, *id); err != nil {
+			return nil, "", err
+		}
+
+		return map[string]uint{"revoked": *id}, fmt.Sprintf("revoked API key %d", *id), nil
+	default:
+		return nil, "", fmt.Errorf("apikey: unknown subcommand %q", args[0])
+	}
+}
+
+func configCmd(e *env, args []string) (interface{}, string, error) {
+	if len(args) < 1 || args[0] != "validate" {
+		return nil, "", errors.New("config: expected validate")
+	}
+
+	if err := e.cfg.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	return map[string]bool{"valid": true}, "config is valid", nil
+}
+
+func generateID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
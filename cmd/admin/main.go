@@ -0,0 +1,163 @@
+// Command admin runs operational jobs against the application database:
+// migrations, fixtures, import/export, purging, API keys and config validation.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"gorm.io/gorm"
+
+	"backend-test/config"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/db"
+	"backend-test/pkg/logger"
+)
+
+const usage = `usage: admin [-json] <command> [flags]
+
+commands:
+  migrate up|down|status    manage schema migrations (down accepts -steps N)
+  seed                      insert the built-in fixture businesses if missing
+  import -file PATH         create businesses from a JSON export
+  export [-file PATH]       write all businesses as JSON (default stdout)
+  purge [-older-than DUR]   permanently remove soft-deleted businesses
+  normalize-ratings         clamp ratings to 0..5 and clear those without reviews
+  apikey issue -name NAME [-scopes read,write,admin]
+                            issue an API key and print it once
+  apikey list               list API keys
+  apikey revoke -id ID      revoke an API key
+  config validate           load and validate the configuration
+
+flags:
+  -json                     print machine-readable JSON output
+`
+
+// env holds what commands need; the database is opened lazily so that
+// commands such as "config validate" work without one.
+type env struct {
+	ctx context.Context
+	cfg *config.Config
+	l   logger.Interface
+	db  *gorm.DB
+}
+
+func (e *env) openDB() (*gorm.DB, error) {
+	if e.db != nil {
+		return e.db, nil
+	}
+
+	conn, err := db.NewGorm(e.cfg, e.l)
+	if err != nil {
+		return nil, fmt.Errorf("admin - openDB - db.NewGorm: %w", err)
+	}
+
+	e.db = conn
+	return conn, nil
+}
+
+func (e *env) businessRepo() (*repo.BusinessRepo, error) {
+	conn, err := e.openDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.NewBusinessRepo(conn, e.l), nil
+}
+
+// command runs with its own arguments and returns a JSON-serializable result
+// plus a human-readable summary.
+type command func(e *env, args []string) (interface{}, string, error)
+
+var commands = map[string]command{
+	"migrate":           migrateCmd,
+	"seed":              seedCmd,
+	"import":            importCmd,
+	"export":            exportCmd,
+	"purge":             purgeCmd,
+	"normalize-ratings": normalizeRatingsCmd,
+	"apikey":            apiKeyCmd,
+	"config":            configCmd,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit status: 0 on
+// success, 1 when the command fails and 2 on usage errors.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("admin", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print machine-readable JSON output")
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return 2
+	}
+
+	name := fs.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	e := &env{ctx: context.Background()}
+
+	// Configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return report(stdout, stderr, *asJSON, name, nil, "", err)
+	}
+
+	e.cfg = cfg
+	// Logs go to stderr, so stdout holds only the command output.
+	e.l = logger.NewWithWriter(stderr, cfg.Log.Level)
+
+	result, summary, err := cmd(e, fs.Args()[1:])
+	return report(stdout, stderr, *asJSON, name, result, summary, err)
+}
+
+type output struct {
+	Command string      `json:"command"`
+	OK      bool        `json:"ok"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// report prints the outcome of a command, as the output envelope with
+// asJSON, and returns its exit status.
+func report(stdout, stderr io.Writer, asJSON bool, name string, result interface{}, summary string, err error) int {
+	if asJSON {
+		out := output{Command: name, OK: err == nil, Result: result}
+		if err != nil {
+			out.Error = err.Error()
+		}
+
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	} else {
+		if summary != "" {
+			fmt.Fprintln(stdout, summary)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", name, err)
+		}
+	}
+
+	if err != nil {
+		return 1
+	}
+	return 0
+}
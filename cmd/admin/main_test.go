@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/db"
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+// inRepoRoot runs the test from the repository root, where config.NewConfig
+// finds config/config.yml.
+func inRepoRoot(t *testing.T) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(filepath.Join("..", "..")); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func runAdmin(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func decodeOutput(t *testing.T, stdout string) output {
+	t.Helper()
+
+	var out output
+	if err := json.Unmarshal([]byte(stdout), &out); err != nil {
+		t.Fatalf("output %q is not the JSON envelope: %v", stdout, err)
+	}
+	return out
+}
+
+func TestConfigValidate(t *testing.T) {
+	inRepoRoot(t)
+
+	code, stdout, _ := runAdmin(t, "-json", "config", "validate")
+	out := decodeOutput(t, stdout)
+	if code != 0 || out.Command != "config" || !out.OK || out.Error != "" {
+		t.Errorf("valid config = %d %+v, want 0 and ok", code, out)
+	}
+	if result, _ := out.Result.(map[string]interface{}); result["valid"] != true {
+		t.Errorf("result = %v, want valid: true", out.Result)
+	}
+
+	code, stdout, _ = runAdmin(t, "config", "validate")
+	if code != 0 || strings.TrimSpace(stdout) != "config is valid" {
+		t.Errorf("text output = %d %q, want config is valid", code, stdout)
+	}
+
+	t.Setenv("HTTP_PORT", "not-a-port")
+	code, stdout, _ = runAdmin(t, "-json", "config", "validate")
+	out = decodeOutput(t, stdout)
+	if code != 1 || out.OK || !strings.Contains(out.Error, "http.port") || out.Result != nil {
+		t.Errorf("invalid config = %d %+v, want 1 and the http.port error", code, out)
+	}
+
+	code, _, stderr := runAdmin(t, "config", "validate")
+	if code != 1 || !strings.Contains(stderr, "config: ") {
+		t.Errorf("text error = %d %q, want 1 and the error on stderr", code, stderr)
+	}
+}
+
+func TestMigrateJSON(t *testing.T) {
+	inRepoRoot(t)
+	t.Setenv("STORAGE_DRIVER", "sqlite")
+	t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "admin.db"))
+
+	code, stdout, _ := runAdmin(t, "-json", "migrate", "up")
+	out := decodeOutput(t, stdout)
+	applied, _ := out.Result.([]interface{})
+	if code != 0 || !out.OK || len(applied) == 0 || applied[0] != "000001_create_businesses" {
+		t.Errorf("migrate up = %d %+v, want the applied migrations", code, out)
+	}
+
+	code, stdout, _ = runAdmin(t, "-json", "migrate", "sideways")
+	if out = decodeOutput(t, stdout); code != 1 || out.OK || out.Error == "" {
+		t.Errorf("unknown subcommand = %d %+v, want 1 and an error", code, out)
+	}
+}
+
+func TestNormalizeRatings(t *testing.T) {
+	inRepoRoot(t)
+	path := filepath.Join(t.TempDir(), "admin.db")
+	t.Setenv("STORAGE_DRIVER", "sqlite")
+	t.Setenv("SQLITE_PATH", path)
+
+	if code, _, stderr := runAdmin(t, "migrate", "up"); code != 0 {
+		t.Fatalf("migrate up = %d %q", code, stderr)
+	}
+
+	conn, err := sqlite.Open(path)
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close(conn) })
+	br := repo.NewBusinessRepo(conn, logger.New("error"))
+	for _, b := range []entity.Business{
+		{ID: "over", Alias: "over", Price: "$", Rating: 7, ReviewCount: 3},
+		{ID: "unreviewed", Alias: "unreviewed", Price: "$", Rating: 4},
+		{ID: "fine", Alias: "fine", Price: "$", Rating: 4, ReviewCount: 2},
+	} {
+		if err := br.Create(context.Background(), b); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	code, stdout, _ := runAdmin(t, "-json", "normalize-ratings")
+	out := decodeOutput(t, stdout)
+	if result, _ := out.Result.(map[string]interface{}); code != 0 || result["count"] != float64(2) {
+		t.Errorf("normalize-ratings = %d %+v, want 2 ratings changed", code, out)
+	}
+
+	for id, want := range map[string]int{"over": 5, "unreviewed": 0, "fine": 4} {
+		b, err := br.ReadById(context.Background(), id)
+		if err != nil {
+			t.Fatalf("ReadById: %v", err)
+		}
+		if b.Rating != want {
+			t.Errorf("rating of %s = %d, want %d", id, b.Rating, want)
+		}
+	}
+}
+
+func TestJSONOutputOnly(t *testing.T) {
+	inRepoRoot(t)
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("STORAGE_DRIVER", "sqlite")
+	t.Setenv("SQLITE_PATH", filepath.Join(t.TempDir(), "admin.db"))
+
+	code, stdout, stderr := runAdmin(t, "-json", "migrate", "status")
+	if code != 0 {
+		t.Fatalf("migrate status = %d, stderr %q", code, stderr)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(stdout))
+	var out output
+	if err := dec.Decode(&out); err != nil || out.Command != "migrate" || !out.OK {
+		t.Fatalf("stdout %q does not start with the JSON envelope: %v", stdout, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		t.Errorf("stdout %q holds more than one JSON document", stdout)
+	}
+	if !strings.Contains(stderr, "db - statement") {
+		t.Errorf("stderr %q, want the debug statement logs there", stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	if code, _, stderr := runAdmin(t); code != 2 || !strings.Contains(stderr, "usage: admin") {
+		t.Errorf("no command = %d %q, want 2 and usage", code, stderr)
+	}
+	if code, _, stderr := runAdmin(t, "frobnicate"); code != 2 || !strings.Contains(stderr, `unknown command "frobnicate"`) {
+		t.Errorf("unknown command = %d %q, want 2", code, stderr)
+	}
+}
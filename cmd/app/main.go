@@ -9,7 +9,7 @@ import (
 
 func main() {
 	// Configuration
-	cfg, err := config.NewConfig()
+	cfg, err := loadConfig()
 	if err != nil {
 		log.Fatalf("Config error: %s", err)
 	}
@@ -17,3 +17,18 @@ func main() {
 	// Run
 	app.Run(cfg)
 }
+
+// loadConfig reads the config and refuses one the server must not start
+// with, such as CORS echoing any origin with credentials.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.NewConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// inRepoRoot runs the test from the repository root, where config.NewConfig
+// finds config/config.yml.
+func inRepoRoot(t *testing.T) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(filepath.Join("..", "..")); err != nil {
+		t.Fatalf("Chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func TestLoadConfig(t *testing.T) {
+	inRepoRoot(t)
+
+	if _, err := loadConfig(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+	cfg, err := loadConfig()
+	if err == nil || !strings.Contains(err.Error(), "cors.allowed_origins") {
+		t.Errorf("wildcard origin with credentials = %v, %v, want the cors error", cfg, err)
+	}
+	if cfg != nil {
+		t.Errorf("config = %+v, want none when invalid", cfg)
+	}
+}
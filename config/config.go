@@ -1,7 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -9,21 +14,131 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App   `yaml:"app"`
-		HTTP  `yaml:"http"`
-		Log   `yaml:"logger"`
-		MYSQL `yaml:"mysql"`
+		App       `yaml:"app"`
+		HTTP      `yaml:"http"`
+		CORS      `yaml:"cors"`
+		Compress  `yaml:"compress"`
+		Health    `yaml:"health"`
+		Auth      `yaml:"auth"`
+		RateLimit `yaml:"rate_limit"`
+		Cache     `yaml:"cache"`
+		Log       `yaml:"logger"`
+		Tracing   `yaml:"tracing"`
+		Storage   `yaml:"storage"`
+		MYSQL     `yaml:"mysql"`
+		Postgres  `yaml:"postgres"`
+		SQLite    `yaml:"sqlite"`
 	}
 
 	// App -.
 	App struct {
 		Name    string `env-required:"true" yaml:"name"    env:"APP_NAME"`
 		Version string `env-required:"true" yaml:"version" env:"APP_VERSION"`
+		// ShutdownTimeout bounds the whole shutdown, including health.drain_delay
+		// and http.shutdown_timeout.
+		ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"APP_SHUTDOWN_TIMEOUT" env-default:"30s"`
 	}
 
 	// HTTP -.
 	HTTP struct {
-		Port string `env-required:"true" yaml:"port" env:"HTTP_PORT"`
+		Port              string        `env-required:"true" yaml:"port" env:"HTTP_PORT"`
+		ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"5s"`
+		ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" env-default:"5s"`
+		WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"60s"`
+		IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" env-default:"120s"`
+		ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"10s"`
+		MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"HTTP_MAX_HEADER_BYTES" env-default:"1048576"`
+		MaxBodyBytes      int64         `yaml:"max_body_bytes" env:"HTTP_MAX_BODY_BYTES" env-default:"1048576"`
+		HTTP2             bool          `yaml:"http2" env:"HTTP_HTTP2" env-default:"true"`
+		H2C               bool          `yaml:"h2c" env:"HTTP_H2C" env-default:"false"`
+		SwaggerUI         bool          `yaml:"swagger_ui" env:"HTTP_SWAGGER_UI" env-default:"false"`
+		// LegacyRoutes also serves the v1 API without its prefix, with a Deprecation header.
+		LegacyRoutes bool `yaml:"legacy_routes" env:"HTTP_LEGACY_ROUTES" env-default:"true"`
+		// TLSCert and TLSKey are PEM files; when both are set the server speaks HTTPS.
+		TLSCert string `yaml:"tls_cert" env:"HTTP_TLS_CERT"`
+		TLSKey  string `yaml:"tls_key" env:"HTTP_TLS_KEY"`
+		// TrustedProxies are the IPs or CIDRs whose X-Forwarded-For and
+		// X-Real-IP headers name the client. Empty trusts none, so the client
+		// is the peer address.
+		TrustedProxies []string `yaml:"trusted_proxies" env:"HTTP_TRUSTED_PROXIES" env-separator:","`
+	}
+
+	// CORS -.
+	CORS struct {
+		// AllowedOrigins are exact origins, origins with a wildcard subdomain
+		// such as https://*.example.com, or *. CORS is off when empty.
+		AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" env-separator:","`
+		AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS" env-separator:"," env-default:"GET,POST,PUT,DELETE"`
+		AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" env-separator:"," env-default:"Authorization,Content-Type,X-API-Key,X-Request-ID"`
+		AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" env-default:"false"`
+		MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" env-default:"10m"`
+	}
+
+	// Compress -.
+	Compress struct {
+		Enabled bool `yaml:"enabled" env:"COMPRESS_ENABLED" env-default:"true"`
+		// MinSize is the smallest response body, in bytes, worth compressing.
+		MinSize int `yaml:"min_size" env:"COMPRESS_MIN_SIZE" env-default:"1024"`
+		// ExcludedTypes are media types, or prefixes such as image/, sent
+		// uncompressed; the built-in list of compressed formats when empty.
+		ExcludedTypes []string `yaml:"excluded_types" env:"COMPRESS_EXCLUDED_TYPES" env-separator:","`
+	}
+
+	// Health -.
+	Health struct {
+		// Timeout bounds a readiness run across all dependencies.
+		Timeout time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT" env-default:"2s"`
+		// DrainDelay is how long /readyz reports failing before the server
+		// stops accepting connections on shutdown.
+		DrainDelay time.Duration `yaml:"drain_delay" env:"HEALTH_DRAIN_DELAY" env-default:"0s"`
+	}
+
+	// Auth -.
+	Auth struct {
+		// PublicRead serves reads and searches without an API key.
+		PublicRead bool `yaml:"public_read" env:"AUTH_PUBLIC_READ" env-default:"true"`
+		JWT        JWT  `yaml:"jwt"`
+	}
+
+	// JWT -.
+	JWT struct {
+		// Secret enables HS256 tokens; JWKSFile enables RS256 tokens signed by
+		// the keys of a local JSON Web Key Set. Tokens are rejected when
+		// neither is set.
+		Secret     string        `yaml:"secret" env:"AUTH_JWT_SECRET"`
+		JWKSFile   string        `yaml:"jwks_file" env:"AUTH_JWT_JWKS_FILE"`
+		Issuer     string        `yaml:"issuer" env:"AUTH_JWT_ISSUER"`
+		Audience   string        `yaml:"audience" env:"AUTH_JWT_AUDIENCE"`
+		RolesClaim string        `yaml:"roles_claim" env:"AUTH_JWT_ROLES_CLAIM" env-default:"roles"`
+		Leeway     time.Duration `yaml:"leeway" env:"AUTH_JWT_LEEWAY" env-default:"30s"`
+		// AllowMissingExp accepts tokens without an exp claim, which never
+		// expire. Tokens must carry one by default.
+		AllowMissingExp bool `yaml:"allow_missing_exp" env:"AUTH_JWT_ALLOW_MISSING_EXP" env-default:"false"`
+	}
+
+	// RateLimit -.
+	RateLimit struct {
+		Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+		// Default applies to the routes without an entry in Routes; zero
+		// requests leaves them unlimited.
+		Default RateLimitRule `yaml:"default" env-prefix:"RATE_LIMIT_DEFAULT_"`
+		// Routes are keyed by method and route without the version prefix,
+		// such as "GET /business/search".
+		Routes map[string]RateLimitRule `yaml:"routes"`
+	}
+
+	// RateLimitRule allows Requests requests per Per, in bursts of up to
+	// Requests.
+	RateLimitRule struct {
+		Requests int           `yaml:"requests" env:"REQUESTS"`
+		Per      time.Duration `yaml:"per" env:"PER" env-default:"1m"`
+	}
+
+	// Cache holds the in-process cache of business reads and searches.
+	Cache struct {
+		Enabled bool          `yaml:"enabled" env:"CACHE_ENABLED" env-default:"true"`
+		Size    int           `yaml:"size"    env:"CACHE_SIZE"    env-default:"1024"`
+		TTL     time.Duration `yaml:"ttl"     env:"CACHE_TTL"     env-default:"30s"`
 	}
 
 	// Log -.
@@ -31,6 +146,43 @@ type (
 		Level string `env-required:"true" yaml:"log_level"   env:"LOG_LEVEL"`
 	}
 
+	// Tracing -.
+	Tracing struct {
+		// Exporter is none, stdout or otlp.
+		Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+		Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT" env-default:"localhost:4318"`
+		Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+	}
+
+	// Storage -.
+	Storage struct {
+		Driver            string        `env-required:"true" yaml:"driver" env:"STORAGE_DRIVER"`
+		MaxOpenConns      int           `yaml:"max_open_conns" env:"STORAGE_MAX_OPEN_CONNS" env-default:"25"`
+		MaxIdleConns      int           `yaml:"max_idle_conns" env:"STORAGE_MAX_IDLE_CONNS" env-default:"10"`
+		ConnMaxLifetime   time.Duration `yaml:"conn_max_lifetime" env:"STORAGE_CONN_MAX_LIFETIME" env-default:"30m"`
+		ConnMaxIdleTime   time.Duration `yaml:"conn_max_idle_time" env:"STORAGE_CONN_MAX_IDLE_TIME" env-default:"5m"`
+		ConnectRetries    int           `yaml:"connect_retries" env:"STORAGE_CONNECT_RETRIES" env-default:"10"`
+		ConnectBackoff    time.Duration `yaml:"connect_backoff" env:"STORAGE_CONNECT_BACKOFF" env-default:"500ms"`
+		ConnectMaxBackoff time.Duration `yaml:"connect_max_backoff" env:"STORAGE_CONNECT_MAX_BACKOFF" env-default:"10s"`
+		QueryTimeouts     QueryTimeouts `yaml:"query_timeouts"`
+		// SlowQueryThreshold logs statements taking longer at warn level; 0
+		// disables it.
+		SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"STORAGE_SLOW_QUERY_THRESHOLD" env-default:"200ms"`
+		// PrintAdminKey prints the admin API key issued for the memory driver
+		// to stderr once, for local development. The log only shows its prefix.
+		PrintAdminKey bool `yaml:"print_admin_key" env:"STORAGE_PRINT_ADMIN_KEY" env-default:"false"`
+	}
+
+	// QueryTimeouts -.
+	QueryTimeouts struct {
+		Create time.Duration `yaml:"create" env:"QUERY_TIMEOUT_CREATE" env-default:"5s"`
+		Read   time.Duration `yaml:"read" env:"QUERY_TIMEOUT_READ" env-default:"2s"`
+		Update time.Duration `yaml:"update" env:"QUERY_TIMEOUT_UPDATE" env-default:"5s"`
+		Delete time.Duration `yaml:"delete" env:"QUERY_TIMEOUT_DELETE" env-default:"5s"`
+		Search time.Duration `yaml:"search" env:"QUERY_TIMEOUT_SEARCH" env-default:"5s"`
+	}
+
 	// MYSQL -.
 	MYSQL struct {
 		Host     string `env-required:"true" yaml:"host" env:"MYSQL_HOST"`
@@ -38,6 +190,36 @@ type (
 		Username string `env-required:"true" yaml:"username" env:"MYSQL_USERNAME"`
 		Password string `env-required:"true" yaml:"password" env:"MYSQL_PASSWORD"`
 		Dbname   string `env-required:"true" yaml:"dbname" env:"MYSQL_DBNAME"`
+
+		DialTimeout  time.Duration `yaml:"dial_timeout" env:"MYSQL_DIAL_TIMEOUT" env-default:"5s"`
+		ReadTimeout  time.Duration `yaml:"read_timeout" env:"MYSQL_READ_TIMEOUT" env-default:"30s"`
+		WriteTimeout time.Duration `yaml:"write_timeout" env:"MYSQL_WRITE_TIMEOUT" env-default:"30s"`
+		// TLS is one of false, true, skip-verify, preferred or custom. custom
+		// uses TLSCA and, for client certificates, TLSCert and TLSKey.
+		TLS     string `yaml:"tls" env:"MYSQL_TLS" env-default:"false"`
+		TLSCA   string `yaml:"tls_ca" env:"MYSQL_TLS_CA"`
+		TLSCert string `yaml:"tls_cert" env:"MYSQL_TLS_CERT"`
+		TLSKey  string `yaml:"tls_key" env:"MYSQL_TLS_KEY"`
+	}
+
+	// Postgres -.
+	Postgres struct {
+		Host     string `yaml:"host" env:"POSTGRES_HOST"`
+		Port     string `yaml:"port" env:"POSTGRES_PORT"`
+		Username string `yaml:"username" env:"POSTGRES_USERNAME"`
+		Password string `yaml:"password" env:"POSTGRES_PASSWORD"`
+		Dbname   string `yaml:"dbname" env:"POSTGRES_DBNAME"`
+		SSLMode  string `yaml:"sslmode" env:"POSTGRES_SSLMODE" env-default:"disable"`
+
+		ConnectTimeout time.Duration `yaml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT" env-default:"5s"`
+		SSLRootCert    string        `yaml:"sslrootcert" env:"POSTGRES_SSLROOTCERT"`
+		SSLCert        string        `yaml:"sslcert" env:"POSTGRES_SSLCERT"`
+		SSLKey         string        `yaml:"sslkey" env:"POSTGRES_SSLKEY"`
+	}
+
+	// SQLite -.
+	SQLite struct {
+		Path string `yaml:"path" env:"SQLITE_PATH"`
 	}
 )
 
@@ -57,3 +239,139 @@ func NewConfig() (*Config, error) {
 
 	return cfg, nil
 }
+
+// Validate reports every semantic problem in the config that the yaml/env
+// loader cannot catch on its own.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, err := strconv.ParseUint(c.HTTP.Port, 10, 16); err != nil {
+		errs = append(errs, fmt.Errorf("http.port %q is not a valid port", c.HTTP.Port))
+	}
+
+	if c.HTTP.ReadTimeout < 0 || c.HTTP.ReadHeaderTimeout < 0 || c.HTTP.WriteTimeout < 0 ||
+		c.HTTP.IdleTimeout < 0 || c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("http timeouts must not be negative and http.shutdown_timeout must be positive"))
+	}
+
+	if c.HTTP.MaxHeaderBytes <= 0 {
+		errs = append(errs, fmt.Errorf("http.max_header_bytes %d must be positive", c.HTTP.MaxHeaderBytes))
+	}
+
+	if c.HTTP.MaxBodyBytes <= 0 {
+		errs = append(errs, fmt.Errorf("http.max_body_bytes %d must be positive", c.HTTP.MaxBodyBytes))
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials {
+			errs = append(errs, errors.New("cors.allowed_origins must list origins, not *, when cors.allow_credentials is set"))
+		}
+	}
+
+	for _, proxy := range c.HTTP.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			errs = append(errs, fmt.Errorf("http.trusted_proxies %q is not an IP or CIDR", proxy))
+		}
+	}
+
+	if (c.HTTP.TLSCert == "") != (c.HTTP.TLSKey == "") {
+		errs = append(errs, errors.New("http.tls_cert and http.tls_key must be set together"))
+	}
+
+	if c.App.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("app.shutdown_timeout must be positive"))
+	} else if c.Health.DrainDelay+c.HTTP.ShutdownTimeout > c.App.ShutdownTimeout {
+		errs = append(errs, errors.New("health.drain_delay plus http.shutdown_timeout exceeds app.shutdown_timeout"))
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			errs = append(errs, errors.New("tracing.endpoint is required when tracing.exporter is otlp"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter %q must be one of none, stdout, otlp", c.Tracing.Exporter))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sample_ratio %v must be between 0 and 1", c.Tracing.SampleRatio))
+	}
+
+	if c.Health.Timeout <= 0 || c.Health.DrainDelay < 0 {
+		errs = append(errs, errors.New("health.timeout must be positive and health.drain_delay not negative"))
+	}
+
+	if c.Storage.MaxOpenConns < 0 || c.Storage.MaxIdleConns < 0 || c.Storage.ConnectRetries < 0 {
+		errs = append(errs, errors.New("storage pool sizes and connect_retries must not be negative"))
+	}
+
+	if c.Auth.JWT.Secret != "" && len(c.Auth.JWT.Secret) < 32 {
+		errs = append(errs, errors.New("auth.jwt.secret must be at least 32 bytes"))
+	}
+
+	if c.Auth.JWT.Leeway < 0 {
+		errs = append(errs, errors.New("auth.jwt.leeway must not be negative"))
+	}
+
+	for route, rule := range c.RateLimit.Routes {
+		if rule.Requests < 0 || rule.Per <= 0 {
+			errs = append(errs, fmt.Errorf("rate_limit.routes[%q] needs requests not negative and per positive", route))
+		}
+	}
+	if c.RateLimit.Default.Requests < 0 || c.RateLimit.Default.Per <= 0 {
+		errs = append(errs, errors.New("rate_limit.default needs requests not negative and per positive"))
+	}
+	if c.Compress.MinSize < 0 {
+		errs = append(errs, fmt.Errorf("compress.min_size %d must not be negative", c.Compress.MinSize))
+	}
+	if c.Cache.Enabled && (c.Cache.Size <= 0 || c.Cache.TTL <= 0) {
+		errs = append(errs, errors.New("cache.size and cache.ttl must be positive when the cache is enabled"))
+	}
+
+	if c.Storage.SlowQueryThreshold < 0 {
+		errs = append(errs, errors.New("storage.slow_query_threshold must not be negative"))
+	}
+
+	if c.Storage.MaxOpenConns > 0 && c.Storage.MaxIdleConns > c.Storage.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("storage.max_idle_conns %d exceeds max_open_conns %d", c.Storage.MaxIdleConns, c.Storage.MaxOpenConns))
+	}
+
+	switch c.Storage.Driver {
+	case "mysql":
+		if _, err := strconv.ParseUint(c.MYSQL.Port, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("mysql.port %q is not a valid port", c.MYSQL.Port))
+		}
+		switch c.MYSQL.TLS {
+		case "false", "true", "skip-verify", "preferred":
+		case "custom":
+			if c.MYSQL.TLSCA == "" {
+				errs = append(errs, errors.New("mysql.tls_ca is required when mysql.tls is custom"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("mysql.tls %q must be one of false, true, skip-verify, preferred, custom", c.MYSQL.TLS))
+		}
+	case "postgres":
+		if c.Postgres.Host == "" || c.Postgres.Username == "" || c.Postgres.Dbname == "" {
+			errs = append(errs, errors.New("postgres.host, postgres.username and postgres.dbname are required"))
+		}
+		if _, err := strconv.ParseUint(c.Postgres.Port, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("postgres.port %q is not a valid port", c.Postgres.Port))
+		}
+	case "sqlite":
+		if c.SQLite.Path == "" {
+			errs = append(errs, errors.New("sqlite.path is required"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("storage.driver %q must be one of mysql, postgres, sqlite, memory", c.Storage.Driver))
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("logger.log_level %q must be one of debug, info, warn, error", c.Log.Level))
+	}
+
+	return errors.Join(errs...)
+}
@@ -2,47 +2,194 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"backend-test/config"
 	"backend-test/internal/business/usecase"
 	"backend-test/internal/business/usecase/repo"
-	v1 "backend-test/internal/controller/http/v1"
-	"backend-test/internal/db/gorm/mysql"
+	"backend-test/internal/controller/http"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/db"
+	"backend-test/internal/db/migrate"
+	"backend-test/internal/entity"
+	"backend-test/pkg/cache"
+	"backend-test/pkg/health"
 	"backend-test/pkg/httpserver"
+	"backend-test/pkg/jwtauth"
 	"backend-test/pkg/logger"
+	"backend-test/pkg/ratelimit"
+	"backend-test/pkg/tracing"
 )
 
 // Run creates objects via constructors.
 func Run(cfg *config.Config) {
 	l := logger.New(cfg.Log.Level)
+	lc := newLifecycle(l)
 
-	db, err := mysql.NewGormMysql(cfg)
+	// Tracing is stopped last, so spans of the shutdown itself are flushed.
+	tp, err := tracing.New(context.Background(),
+		tracing.Exporter(cfg.Tracing.Exporter),
+		tracing.Endpoint(cfg.Tracing.Endpoint),
+		tracing.Insecure(cfg.Tracing.Insecure),
+		tracing.SampleRatio(cfg.Tracing.SampleRatio),
+		tracing.Service(cfg.App.Name, cfg.App.Version),
+	)
 	if err != nil {
-		l.Fatal(fmt.Errorf("app - Run - db.New: %w", err))
+		l.Fatal(fmt.Errorf("app - Run - tracing.New: %w", err))
 	}
+	lc.Append("tracing", nil, tp.Shutdown)
 
-	bussinessRepo := repo.NewBusinessRepo(db, l)
-
-	if err := bussinessRepo.MigrateAndMockData(); err != nil {
-		l.Fatal(fmt.Errorf("app - Run - businessRepo.MigrateAndMock: %w", err))
+	st, err := newStorage(cfg, l)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - newStorage: %w", err))
 	}
+	lc.Append("storage", nil, func(context.Context) error {
+		if st.close == nil {
+			return nil
+		}
+		return st.close()
+	})
 
 	// Use case
-	businessUseCase := usecase.NewBusinessUseCase(
-		bussinessRepo,
-		l,
+	var (
+		business usecase.Business = usecase.NewBusinessUseCase(
+			st.business,
+			st.audit,
+			st.uow,
+			l,
+		)
+		invalidator usecase.Invalidator
 	)
+	if cfg.Cache.Enabled {
+		cached := usecase.NewCachedBusiness(business, cache.NewLRU(cfg.Cache.Size), cfg.Cache.TTL, l)
+		business, invalidator = cached, cached
+	}
+	businessUseCase := usecase.NewTracedBusiness(business)
+
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(st.apiKeys, l)
+	claimUseCase := usecase.NewClaimUseCase(st.claims, st.business, st.audit, st.uow, invalidator, l)
+
+	// The in-memory store starts empty and cannot be reached by cmd/admin, so
+	// it gets an admin key for local use.
+	if cfg.Storage.Driver == "memory" {
+		k, key, err := apiKeyUseCase.Issue(context.Background(), "memory-admin", []string{entity.ScopeAdmin})
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - apiKeyUseCase.Issue: %w", err))
+		}
+		l.Warn("app - Run - in-memory storage: issued admin API key %d with prefix %s", k.ID, k.Prefix)
+		if cfg.Storage.PrintAdminKey {
+			fmt.Fprintf(os.Stderr, "in-memory storage admin API key: %s\n", key)
+		}
+	}
+
+	// Health
+	hc := health.New(health.Timeout(cfg.Health.Timeout))
+	if st.ping != nil {
+		hc.Register("database", st.ping)
+	}
+
+	// Dashboard users authenticate with JWTs when a key is configured.
+	routerOpts := []http.Option{
+		http.SwaggerUI(cfg.HTTP.SwaggerUI),
+		http.LegacyRoutes(cfg.HTTP.LegacyRoutes),
+		http.PublicRead(cfg.Auth.PublicRead),
+		http.MaxBodyBytes(cfg.HTTP.MaxBodyBytes),
+	}
+	if len(cfg.CORS.AllowedOrigins) > 0 {
+		routerOpts = append(routerOpts, http.CORS(middleware.CORSOptions{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		}))
+	}
+	if cfg.Compress.Enabled {
+		o := middleware.CompressOptions{MinSize: cfg.Compress.MinSize}
+		if len(cfg.Compress.ExcludedTypes) > 0 {
+			o.ExcludedTypes = cfg.Compress.ExcludedTypes
+		}
+		routerOpts = append(routerOpts, http.Compress(o))
+	}
+	if jwtCfg := cfg.Auth.JWT; jwtCfg.Secret != "" || jwtCfg.JWKSFile != "" {
+		tokens, err := jwtauth.New(
+			jwtauth.Secret(jwtCfg.Secret),
+			jwtauth.JWKSFile(jwtCfg.JWKSFile),
+			jwtauth.Issuer(jwtCfg.Issuer),
+			jwtauth.Audience(jwtCfg.Audience),
+			jwtauth.RolesClaim(jwtCfg.RolesClaim),
+			jwtauth.Leeway(jwtCfg.Leeway),
+			jwtauth.AllowMissingExpiry(jwtCfg.AllowMissingExp),
+		)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - jwtauth.New: %w", err))
+		}
+		routerOpts = append(routerOpts, http.Tokens(tokens))
+	}
+	if rl := cfg.RateLimit; rl.Enabled {
+		routes := make(map[string]ratelimit.Limit, len(rl.Routes))
+		for route, rule := range rl.Routes {
+			routes[route] = ratelimit.Limit{Requests: rule.Requests, Per: rule.Per}
+		}
+		routerOpts = append(routerOpts, http.RateLimit(
+			ratelimit.NewMemoryStore(),
+			ratelimit.Limit{Requests: rl.Default.Requests, Per: rl.Default.Per},
+			routes,
+		))
+	}
 
 	// HTTP Server
 	handler := gin.New()
-	v1.NewRouter(handler, l, businessUseCase)
-	httpServer := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
+	if err = handler.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - handler.SetTrustedProxies: %w", err))
+	}
+	http.NewRouter(handler, l, businessUseCase, apiKeyUseCase, claimUseCase, hc, routerOpts...)
+
+	var httpServer *httpserver.Server
+	lc.Append("http server", func(context.Context) error {
+		httpServer = httpserver.New(handler,
+			httpserver.Port(cfg.HTTP.Port),
+			httpserver.ReadTimeout(cfg.HTTP.ReadTimeout),
+			httpserver.ReadHeaderTimeout(cfg.HTTP.ReadHeaderTimeout),
+			httpserver.WriteTimeout(cfg.HTTP.WriteTimeout),
+			httpserver.IdleTimeout(cfg.HTTP.IdleTimeout),
+			httpserver.ShutdownTimeout(cfg.HTTP.ShutdownTimeout),
+			httpserver.MaxHeaderBytes(cfg.HTTP.MaxHeaderBytes),
+			httpserver.HTTP2(cfg.HTTP.HTTP2),
+			httpserver.H2C(cfg.HTTP.H2C),
+			httpserver.TLS(cfg.HTTP.TLSCert, cfg.HTTP.TLSKey),
+		)
+		return nil
+	}, func(ctx context.Context) error {
+		return httpServer.Shutdown(ctx)
+	})
+
+	// Readiness flips before the server stops, so load balancers drain first.
+	lc.Append("readiness", nil, func(ctx context.Context) error {
+		hc.Shutdown()
+		if cfg.Health.DrainDelay <= 0 {
+			return nil
+		}
+
+		l.Info("app - Run - draining for " + cfg.Health.DrainDelay.String())
+		select {
+		case <-time.After(cfg.Health.DrainDelay):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	if err = lc.Start(context.Background()); err != nil {
+		l.Fatal(fmt.Errorf("app - Run - lc.Start: %w", err))
+	}
 
 	// Waiting signal
 	interrupt := make(chan os.Signal, 1)
@@ -56,9 +203,81 @@ func Run(cfg *config.Config) {
 	}
 
 	// Shutdown
-	err = httpServer.Shutdown()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	if err = lc.Stop(ctx); err != nil {
+		l.Error(fmt.Errorf("app - Run - lc.Stop: %w", err))
+	}
+}
+
+// storage groups the repositories and the unit of work sharing one backend.
+type storage struct {
+	business usecase.BusinessRepo
+	audit    usecase.AuditRepo
+	apiKeys  usecase.APIKeyRepo
+	claims   usecase.ClaimRepo
+	uow      usecase.UnitOfWork
+	// ping and close act on the database; nil for the in-memory driver.
+	ping  health.Check
+	close func() error
+}
+
+// newStorage builds the repositories selected by cfg.Storage.Driver.
+func newStorage(cfg *config.Config, l logger.Interface) (storage, error) {
+	if cfg.Storage.Driver == "memory" {
+		l.Warn("app - newStorage: using in-memory storage, data is lost on exit")
+
+		business := repo.NewBusinessMemoryRepo(l)
+		audit := repo.NewAuditMemoryRepo()
+		claims := repo.NewClaimMemoryRepo()
+
+		return storage{
+			business: business,
+			audit:    audit,
+			apiKeys:  repo.NewAPIKeyMemoryRepo(),
+			claims:   claims,
+			uow:      repo.NewMemoryUnitOfWork(business, audit, claims),
+		}, nil
+	}
+
+	conn, err := db.NewGorm(cfg, l)
 	if err != nil {
-		l.Error(fmt.Errorf("app - Run - httpServer.Shutdown: %w", err))
+		return storage{}, fmt.Errorf("db.NewGorm: %w", err)
 	}
 
+	if err := db.RegisterMetrics(conn, cfg.Storage.Driver); err != nil {
+		return storage{}, fmt.Errorf("db.RegisterMetrics: %w", err)
+	}
+
+	if err := db.RegisterTracing(conn); err != nil {
+		return storage{}, fmt.Errorf("db.RegisterTracing: %w", err)
+	}
+
+	migrator, err := migrate.New(conn)
+	if err != nil {
+		return storage{}, fmt.Errorf("migrate.New: %w", err)
+	}
+
+	if err := migrator.Check(context.Background()); err != nil {
+		return storage{}, fmt.Errorf("migrator.Check: %w", err)
+	}
+
+	qt := cfg.Storage.QueryTimeouts
+
+	return storage{
+		business: repo.NewBusinessRepo(conn, l, repo.QueryTimeouts(repo.Timeouts{
+			Create: qt.Create,
+			Read:   qt.Read,
+			Update: qt.Update,
+			Delete: qt.Delete,
+			Search: qt.Search,
+		})),
+		audit:   repo.NewAuditRepo(conn),
+		apiKeys: repo.NewAPIKeyRepo(conn),
+		claims:  repo.NewClaimRepo(conn),
+		uow:     repo.NewGormUnitOfWork(conn),
+		ping:    db.Ping(conn),
+		close:   func() error { return db.Close(conn) },
+	}, nil
 }
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend-test/pkg/logger"
+)
+
+// hook is a component's start and stop functions; either may be nil.
+type hook struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// lifecycle starts components in registration order and stops the started
+// ones in reverse, so a component is stopped before the ones it depends on.
+type lifecycle struct {
+	l       logger.Interface
+	hooks   []hook
+	started int
+}
+
+func newLifecycle(l logger.Interface) *lifecycle {
+	return &lifecycle{l: l}
+}
+
+// Append registers a component. Components must be appended after the ones
+// they depend on.
+func (lc *lifecycle) Append(name string, start, stop func(ctx context.Context) error) {
+	lc.hooks = append(lc.hooks, hook{name: name, start: start, stop: stop})
+}
+
+// Start runs the start hooks in order. If one fails, the components already
+// started are stopped within ctx and the start error is returned.
+func (lc *lifecycle) Start(ctx context.Context) error {
+	for _, h := range lc.hooks {
+		if h.start != nil {
+			if err := h.start(ctx); err != nil {
+				err = fmt.Errorf("start %s: %w", h.name, err)
+				lc.l.Error(fmt.Errorf("app - lifecycle - %w", err))
+				return errors.Join(err, lc.Stop(ctx))
+			}
+			lc.l.Info("app - lifecycle - started " + h.name)
+		}
+		lc.started++
+	}
+
+	return nil
+}
+
+// Stop runs the stop hooks of started components in reverse order, one at a
+// time: a hook returns before the next one, which may depend on it, is
+// called. ctx is the deadline for the whole shutdown and is passed to every
+// hook. Once it expires the remaining hooks are still called, so resources are
+// released, and are expected to return promptly.
+func (lc *lifecycle) Stop(ctx context.Context) error {
+	var errs []error
+
+	for ; lc.started > 0; lc.started-- {
+		h := lc.hooks[lc.started-1]
+		if h.stop == nil {
+			continue
+		}
+
+		start := time.Now()
+		late := ctx.Err() != nil
+		err := h.stop(ctx)
+		// Only the hook that was running when the deadline passed overran it.
+		if !late && ctx.Err() != nil {
+			lc.l.Warn("app - lifecycle - stop %s overran the shutdown deadline", h.name)
+		}
+		if err != nil {
+			lc.l.Error(fmt.Errorf("app - lifecycle - stop %s after %s: %w", h.name, time.Since(start), err))
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.name, err))
+			continue
+		}
+		lc.l.Info(fmt.Sprintf("app - lifecycle - stopped %s in %s", h.name, time.Since(start)))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"backend-test/pkg/logger"
+)
+
+// recorder appends the hooks it builds to calls as they run.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) hook(name string, err error) func(context.Context) error {
+	return func(context.Context) error {
+		r.record(name)
+		return err
+	}
+}
+
+func (r *recorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, name)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// warnings records the warnings logged through it.
+type warnings struct {
+	logger.Interface
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *warnings) Warn(message string, args ...interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, fmt.Sprintf(message, args...))
+}
+
+func (w *warnings) get() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.messages...)
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	r := &recorder{}
+	lc := newLifecycle(logger.New("error"))
+	lc.Append("db", r.hook("start db", nil), r.hook("stop db", nil))
+	lc.Append("cache", nil, r.hook("stop cache", nil))
+	lc.Append("server", r.hook("start server", nil), r.hook("stop server", nil))
+
+	if err := lc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := lc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	want := []string{"start db", "start server", "stop server", "stop cache", "stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	if err := lc.Stop(context.Background()); err != nil || len(r.get()) != len(want) {
+		t.Errorf("second Stop = %v, calls %v, want nothing stopped twice", err, r.get())
+	}
+}
+
+func TestLifecycleStartFailure(t *testing.T) {
+	r := &recorder{}
+	errStart := errors.New("port in use")
+	errStop := errors.New("flush failed")
+
+	lc := newLifecycle(logger.New("error"))
+	lc.Append("db", nil, r.hook("stop db", nil))
+	lc.Append("cache", nil, r.hook("stop cache", errStop))
+	lc.Append("server", r.hook("start server", errStart), r.hook("stop server", nil))
+	lc.Append("worker", r.hook("start worker", nil), r.hook("stop worker", nil))
+
+	err := lc.Start(context.Background())
+	if !errors.Is(err, errStart) || !errors.Is(err, errStop) {
+		t.Errorf("Start = %v, want the start and the rollback errors", err)
+	}
+
+	want := []string{"start server", "stop cache", "stop db"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestLifecycleDeadline(t *testing.T) {
+	r := &recorder{}
+	release := make(chan struct{})
+	w := &warnings{Interface: logger.New("error")}
+
+	lc := newLifecycle(w)
+	lc.Append("cache", nil, r.hook("stop cache", nil))
+	lc.Append("db", nil, func(ctx context.Context) error {
+		r.record("stop db")
+		return ctx.Err()
+	})
+	lc.Append("server", nil, func(ctx context.Context) error {
+		<-ctx.Done()
+		// Overruns the deadline; db must not be stopped under it.
+		<-release
+		r.record("stop server")
+		return ctx.Err()
+	})
+	if err := lc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- lc.Stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Stop returned %v while the server hook was still running", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := r.get(); len(got) != 0 {
+		t.Errorf("calls while the server hook overruns = %v, want none", got)
+	}
+
+	close(release)
+	err := <-done
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop = %v, want the deadline", err)
+	}
+	want := []string{"stop server", "stop db", "stop cache"}
+	if got := r.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+
+	// Only the server hook overran; db and cache started past the deadline.
+	if got := w.get(); len(got) != 1 || !strings.Contains(got[0], "stop server overran") {
+		t.Errorf("warnings = %q, want one naming the server hook", got)
+	}
+}
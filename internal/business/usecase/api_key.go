@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+const (
+	// _apiKeyPrefix marks the keys issued by this service, so leaked ones are
+	// easy to recognise.
+	_apiKeyPrefix = "bk_"
+	// _apiKeyBytes is the entropy of a key.
+	_apiKeyBytes = 24
+	// _apiKeyShown is how much of a key is stored in the clear to identify it.
+	_apiKeyShown = len(_apiKeyPrefix) + 8
+)
+
+// ErrInvalidScope is returned when a key is issued with an unknown scope.
+var ErrInvalidScope = errors.New("scopes must be read, write or admin")
+
+// APIKeyUseCase -.
+type APIKeyUseCase struct {
+	repo APIKeyRepo
+	l    logger.Interface
+}
+
+// NewAPIKeyUseCase -.
+func NewAPIKeyUseCase(r APIKeyRepo, l logger.Interface) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		repo: r,
+		l:    l,
+	}
+}
+
+// Issue -.
+func (ku *APIKeyUseCase) Issue(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error) {
+	if name == "" || len(scopes) == 0 {
+		return entity.APIKey{}, "", fmt.Errorf("usecase - Issue - %w: name and scopes are required", ErrInvalidScope)
+	}
+	for _, s := range scopes {
+		if !entity.ValidScope(s) {
+			return entity.APIKey{}, "", fmt.Errorf("usecase - Issue - %w, got %q", ErrInvalidScope, s)
+		}
+	}
+
+	secret := make([]byte, _apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return entity.APIKey{}, "", fmt.Errorf("usecase - Issue - rand.Read: %w", err)
+	}
+	plain := _apiKeyPrefix + hex.EncodeToString(secret)
+
+	k, err := ku.repo.Create(ctx, entity.APIKey{
+		Name:   name,
+		Prefix: plain[:_apiKeyShown],
+		Hash:   hashAPIKey(plain),
+		Scopes: strings.Join(scopes, " "),
+	})
+	if err != nil {
+		ku.log(ctx).Error(fmt.Errorf("usecase - Issue - repo.Create: %w", err))
+		return entity.APIKey{}, "", err
+	}
+
+	ku.log(ctx).Info("usecase - Issue - issued API key %d (%s) with scopes %s", k.ID, k.Prefix, k.Scopes)
+	return k, plain, nil
+}
+
+// Revoke -.
+func (ku *APIKeyUseCase) Revoke(ctx context.Context, id uint) error {
+	if err := ku.repo.Revoke(ctx, id, time.Now()); err != nil {
+		ku.log(ctx).Error(fmt.Errorf("usecase - Revoke - repo.Revoke: %w", err))
+		return err
+	}
+
+	ku.log(ctx).Info("usecase - Revoke - revoked API key %d", id)
+	return nil
+}
+
+// List -.
+func (ku *APIKeyUseCase) List(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := ku.repo.List(ctx)
+	if err != nil {
+		ku.log(ctx).Error(fmt.Errorf("usecase - List - repo.List: %w", err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Authenticate -.
+func (ku *APIKeyUseCase) Authenticate(ctx context.Context, key string) (entity.Principal, error) {
+	if !strings.HasPrefix(key, _apiKeyPrefix) {
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+
+	k, err := ku.repo.FindByHash(ctx, hashAPIKey(key))
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		return entity.Principal{}, entity.ErrUnauthenticated
+	case err != nil:
+		ku.log(ctx).Error(fmt.Errorf("usecase - Authenticate - repo.FindByHash: %w", err))
+		return entity.Principal{}, err
+	case k.Revoked():
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+
+	return entity.Principal{
+		Subject: entity.APIKeySubject(k.ID),
+		Scopes:  k.ScopeList(),
+	}, nil
+}
+
+// log returns the logger of the request in ctx, falling back to ku.l.
+func (ku *APIKeyUseCase) log(ctx context.Context) logger.Interface {
+	return logger.FromContext(ctx, ku.l)
+}
+
+// hashAPIKey is the stored form of a key. Keys are long random strings, so an
+// unsalted fast hash is enough to make a leaked table useless.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
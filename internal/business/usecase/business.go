@@ -15,28 +15,43 @@ import (
 
 // BusinessUseCase -.
 type BusinessUseCase struct {
-	repo BusinessRepo
-	l    logger.Interface
-	v    *validator.Validate
+	repo  BusinessRepo
+	audit AuditRepo
+	uow   UnitOfWork
+	l     logger.Interface
+	v     *validator.Validate
 }
 
 // New -.
-func NewBusinessUseCase(r BusinessRepo, l logger.Interface) *BusinessUseCase {
+func NewBusinessUseCase(r BusinessRepo, a AuditRepo, uow UnitOfWork, l logger.Interface) *BusinessUseCase {
 	return &BusinessUseCase{
-		repo: r,
-		l:    l,
-		v:    validator.New(),
+		repo:  r,
+		audit: a,
+		uow:   uow,
+		l:     l,
+		v:     validator.New(),
 	}
 }
 
 func (bu *BusinessUseCase) Create(ctx context.Context, b entity.Business) error {
+	if err := requireRole(ctx, entity.RoleEditor); err != nil {
+		bu.log(ctx).Warn("usecase - Create - %s", err)
+		return err
+	}
+
 	b.ID = generateRandomToken(16)
 	if err := bu.v.Struct(&b); err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Create - validate: %w", err))
+		bu.log(ctx).Error(fmt.Errorf("usecase - Create - validate: %w", err))
 		return err
 	}
-	if err := bu.repo.Create(ctx, b); err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Create - repo.Create: %w", err))
+	err := bu.uow.Do(ctx, func(ctx context.Context) error {
+		if err := bu.repo.Create(ctx, b); err != nil {
+			return fmt.Errorf("repo.Create: %w", err)
+		}
+		return bu.record(ctx, entity.AuditCreate, b.ID)
+	})
+	if err != nil {
+		bu.log(ctx).Error(fmt.Errorf("usecase - Create - %w", err))
 		return err
 	}
 	return nil
@@ -45,27 +60,47 @@ func (bu *BusinessUseCase) Create(ctx context.Context, b entity.Business) error
 func (bu *BusinessUseCase) Read(ctx context.Context, id string) (entity.Business, error) {
 	business, err := bu.repo.ReadById(ctx, id)
 	if err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Read - repo.ReadById: %w", err))
+		bu.log(ctx).Error(fmt.Errorf("usecase - Read - repo.ReadById: %w", err))
 		return business, err
 	}
 	return business, nil
 }
 
 func (bu *BusinessUseCase) Update(ctx context.Context, id string, b entity.Business) error {
-	if err := bu.repo.UpdateById(ctx, id, b); err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Create - repo.UpdateById: %w", err))
+	err := bu.uow.Do(ctx, func(ctx context.Context) error {
+		if err := bu.authorizeUpdate(ctx, id); err != nil {
+			return err
+		}
+		if err := bu.repo.UpdateById(ctx, id, b); err != nil {
+			return fmt.Errorf("repo.UpdateById: %w", err)
+		}
+		return bu.record(ctx, entity.AuditUpdate, id)
+	})
+	if err != nil {
+		bu.log(ctx).Error(fmt.Errorf("usecase - Update - %w", err))
 		return err
 	}
-	bu.l.Info("usecase - Create - repo.UpdateById: 1 row updated")
+	bu.log(ctx).Info("usecase - Update - repo.UpdateById: 1 row updated")
 	return nil
 }
 
 func (bu *BusinessUseCase) Delete(ctx context.Context, id string) error {
-	if err := bu.repo.DeleteById(ctx, id); err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Create - repo.DeleteById: %w", err))
+	if err := requireRole(ctx, entity.RoleAdmin); err != nil {
+		bu.log(ctx).Warn("usecase - Delete - %s", err)
+		return err
+	}
+
+	err := bu.uow.Do(ctx, func(ctx context.Context) error {
+		if err := bu.repo.DeleteById(ctx, id); err != nil {
+			return fmt.Errorf("repo.DeleteById: %w", err)
+		}
+		return bu.record(ctx, entity.AuditDelete, id)
+	})
+	if err != nil {
+		bu.log(ctx).Error(fmt.Errorf("usecase - Delete - %w", err))
 		return err
 	}
-	bu.l.Info("usecase - Create - repo.DeleteById: 1 row deleted")
+	bu.log(ctx).Info("usecase - Delete - repo.DeleteById: 1 row deleted")
 	return nil
 }
 
@@ -75,22 +110,82 @@ func (bu *BusinessUseCase) Search(ctx context.Context, sp entity.SearchBusinessP
 	}
 
 	var tm time.Time
-	fmt.Println(sp.OpenNow)
 	if sp.OpenNow {
 		tm = time.Now()
 	} else if sp.OpenAt > 0 {
 		tm = time.Unix(int64(sp.OpenAt), 0)
 	}
 
-	businesses, err := bu.repo.Search(ctx, sp.Limit, sp.Offset, sp.Price, sp.Attributes, sp.Categories, tm)
+	near := entity.GeoFilter{
+		Center: entity.Cordinates{Latitude: sp.Latitude, Longitude: sp.Longitude},
+		Radius: sp.Radius,
+	}
+
+	businesses, err := bu.repo.Search(ctx, sp.Limit, sp.Offset, sp.Price, sp.Attributes, sp.Categories, tm, near)
 	if err != nil {
-		bu.l.Error(fmt.Errorf("usecase - Search - repo.Search: %w", err))
+		bu.log(ctx).Error(fmt.Errorf("usecase - Search - repo.Search: %w", err))
 		return []entity.Business{}, err
 	}
 	return businesses, nil
 
 }
 
+// authorizeUpdate lets editors update any business and other callers only
+// the businesses they own.
+func (bu *BusinessUseCase) authorizeUpdate(ctx context.Context, id string) error {
+	p, ok := entity.PrincipalFrom(ctx)
+	if !ok {
+		return entity.ErrUnauthenticated
+	}
+	if p.HasRole(entity.RoleEditor) {
+		return nil
+	}
+
+	current, err := bu.repo.ReadById(ctx, id)
+	if err != nil {
+		return fmt.Errorf("repo.ReadById: %w", err)
+	}
+	if current.OwnerID == "" || current.OwnerID != p.Subject {
+		return fmt.Errorf("%w: %s is neither an editor nor the owner", entity.ErrForbidden, p.Subject)
+	}
+
+	return nil
+}
+
+// requireRole fails unless the caller in ctx has role.
+func requireRole(ctx context.Context, role string) error {
+	p, ok := entity.PrincipalFrom(ctx)
+	if !ok {
+		return entity.ErrUnauthenticated
+	}
+	if !p.HasRole(role) {
+		return fmt.Errorf("%w: %s is not %s", entity.ErrForbidden, p.Subject, role)
+	}
+	return nil
+}
+
+// record writes an audit entry for a business; it must run inside the unit of
+// work of the change it describes.
+func (bu *BusinessUseCase) record(ctx context.Context, action, id string) error {
+	entry := entity.AuditEntry{
+		Action:   action,
+		Entity:   "business",
+		EntityID: id,
+	}
+	if p, ok := entity.PrincipalFrom(ctx); ok {
+		entry.Actor = p.Subject
+	}
+	if err := bu.audit.Record(ctx, entry); err != nil {
+		return fmt.Errorf("audit.Record: %w", err)
+	}
+	return nil
+}
+
+// log returns the logger of the request in ctx, falling back to bu.l.
+func (bu *BusinessUseCase) log(ctx context.Context) logger.Interface {
+	return logger.FromContext(ctx, bu.l)
+}
+
 func generateRandomToken(length int) string {
 	b := make([]byte, length)
 	if _, err := rand.Read(b); err != nil {
@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"backend-test/internal/entity"
+	"backend-test/pkg/cache"
+	"backend-test/pkg/logger"
+)
+
+const (
+	_cacheReadPrefix    = "business:read:"
+	_cacheBarePrefix    = "business:read-bare:"
+	_cacheSearchPrefix  = "business:search:"
+	_cacheGenerationKey = "business:search:generation"
+	// _cacheMaxLimit mirrors the cap BusinessUseCase.Search puts on limits,
+	// so limits above it share one entry.
+	_cacheMaxLimit = 100
+)
+
+// CachedBusiness wraps a Business with a read-through cache of Read and
+// Search results.
+//
+// Writes delete the cached reads of the business they touch. A write may
+// change the result of any search, so it also starts a new search
+// generation: search keys include the generation, which orphans every cached
+// search at once. Orphans leave the backend through eviction or their TTL.
+// Searches on open_now depend on the clock and are never cached. Results
+// loaded without categories, for a fieldset leaving them out, are cached apart
+// from full ones.
+//
+// A read racing a write may put back the value the write replaced; the TTL
+// bounds how long it is served.
+type CachedBusiness struct {
+	next  Business
+	cache cache.Backend
+	ttl   time.Duration
+	l     logger.Interface
+}
+
+var (
+	_ Business    = (*CachedBusiness)(nil)
+	_ Invalidator = (*CachedBusiness)(nil)
+)
+
+// NewCachedBusiness -.
+func NewCachedBusiness(next Business, c cache.Backend, ttl time.Duration, l logger.Interface) *CachedBusiness {
+	return &CachedBusiness{
+		next:  next,
+		cache: c,
+		ttl:   ttl,
+		l:     l,
+	}
+}
+
+func (cb *CachedBusiness) Create(ctx context.Context, b entity.Business) error {
+	if err := cb.next.Create(ctx, b); err != nil {
+		return err
+	}
+	cb.Invalidate(ctx, "")
+	return nil
+}
+
+func (cb *CachedBusiness) Read(ctx context.Context, id string) (entity.Business, error) {
+	key := _cacheReadPrefix + id
+	if !entity.FieldsetFrom(ctx).Has("categories") {
+		key = _cacheBarePrefix + id
+	}
+
+	var cached cachedBusiness
+	if cb.get(ctx, key, &cached) {
+		return cached.business(), nil
+	}
+
+	b, err := cb.next.Read(ctx, id)
+	if err != nil {
+		return b, err
+	}
+	cb.set(ctx, key, newCachedBusiness(b))
+	return b, nil
+}
+
+func (cb *CachedBusiness) Search(ctx context.Context, sp entity.SearchBusinessParam) ([]entity.Business, error) {
+	if sp.OpenNow {
+		return cb.next.Search(ctx, sp)
+	}
+
+	key := _cacheSearchPrefix + cb.generation(ctx) + ":" + searchKey(sp, entity.FieldsetFrom(ctx).Has("categories"))
+
+	var cached []cachedBusiness
+	if cb.get(ctx, key, &cached) {
+		businesses := make([]entity.Business, 0, len(cached))
+		for _, c := range cached {
+			businesses = append(businesses, c.business())
+		}
+		return businesses, nil
+	}
+
+	businesses, err := cb.next.Search(ctx, sp)
+	if err != nil {
+		return businesses, err
+	}
+
+	toCache := make([]cachedBusiness, 0, len(businesses))
+	for _, b := range businesses {
+		toCache = append(toCache, newCachedBusiness(b))
+	}
+	cb.set(ctx, key, toCache)
+	return businesses, nil
+}
+
+func (cb *CachedBusiness) Update(ctx context.Context, id string, b entity.Business) error {
+	if err := cb.next.Update(ctx, id, b); err != nil {
+		return err
+	}
+	cb.Invalidate(ctx, id)
+	return nil
+}
+
+func (cb *CachedBusiness) Delete(ctx context.Context, id string) error {
+	if err := cb.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	cb.Invalidate(ctx, id)
+	return nil
+}
+
+// searchKey normalizes sp so that equivalent searches share an entry: limits
+// are capped like the use case does, category and attribute filters are
+// order-insensitive sets, and the center is ignored without a radius.
+// categories tells whether the results carry their categories.
+func searchKey(sp entity.SearchBusinessParam, categories bool) string {
+	if sp.Limit > _cacheMaxLimit {
+		sp.Limit = _cacheMaxLimit
+	}
+	if sp.Radius <= 0 {
+		sp.Radius, sp.Latitude, sp.Longitude = 0, 0, 0
+	}
+
+	f := func(v float64) string { return strconv.FormatFloat(v, 'g', -1, 64) }
+
+	return strings.Join([]string{
+		"l=" + strconv.FormatUint(uint64(sp.Limit), 10),
+		"o=" + strconv.FormatUint(uint64(sp.Offset), 10),
+		"c=" + normalizedSet(sp.Categories),
+		"a=" + normalizedSet(sp.Attributes),
+		"r=" + f(sp.Radius),
+		"lat=" + f(sp.Latitude),
+		"lng=" + f(sp.Longitude),
+		"p=" + strconv.FormatUint(uint64(sp.Price), 10),
+		"t=" + strconv.FormatUint(uint64(sp.OpenAt), 10),
+		"cat=" + strconv.FormatBool(categories),
+	}, "&")
+}
+
+// normalizedSet sorts and deduplicates values and joins them, escaping the
+// separator so distinct sets never collide.
+func normalizedSet(values []string) string {
+	set := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			set = append(set, strconv.Quote(v))
+		}
+	}
+	sort.Strings(set)
+	return strings.Join(set, ",")
+}
+
+// generation returns the current search generation, starting a new one when
+// the backend has none, for instance after evicting it.
+func (cb *CachedBusiness) generation(ctx context.Context) string {
+	gen, ok, err := cb.cache.Get(ctx, _cacheGenerationKey)
+	if err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - cache.Get: %s", err)
+	}
+	if ok {
+		return string(gen)
+	}
+	return cb.newGeneration(ctx)
+}
+
+func (cb *CachedBusiness) newGeneration(ctx context.Context) string {
+	gen := generateRandomToken(8)
+	if err := cb.cache.Set(ctx, _cacheGenerationKey, []byte(gen), 0); err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - cache.Set: %s", err)
+	}
+	return gen
+}
+
+// Invalidate drops the cached reads of id, if any, and every cached search.
+// Failures are logged: the write already happened, and entries that could
+// not be dropped expire with their TTL.
+func (cb *CachedBusiness) Invalidate(ctx context.Context, id string) {
+	if id != "" {
+		if err := cb.cache.Delete(ctx, _cacheReadPrefix+id, _cacheBarePrefix+id); err != nil {
+			cb.log(ctx).Warn("usecase - CachedBusiness - cache.Delete: %s", err)
+		}
+	}
+	cb.newGeneration(ctx)
+}
+
+// get decodes the entry at key into v and reports whether it was there.
+// Backend and decoding errors count as misses.
+func (cb *CachedBusiness) get(ctx context.Context, key string, v interface{}) bool {
+	data, ok, err := cb.cache.Get(ctx, key)
+	if err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - cache.Get: %s", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - json.Unmarshal %s: %s", key, err)
+		return false
+	}
+	return true
+}
+
+func (cb *CachedBusiness) set(ctx context.Context, key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - json.Marshal %s: %s", key, err)
+		return
+	}
+	if err := cb.cache.Set(ctx, key, data, cb.ttl); err != nil {
+		cb.log(ctx).Warn("usecase - CachedBusiness - cache.Set: %s", err)
+	}
+}
+
+// log returns the logger of the request in ctx, falling back to cb.l.
+func (cb *CachedBusiness) log(ctx context.Context) logger.Interface {
+	return logger.FromContext(ctx, cb.l)
+}
+
+// cachedBusiness is the cache encoding of a business. It also carries the
+// fields entity.Business hides from API responses.
+type cachedBusiness struct {
+	entity.Business
+	UUID      uint           `json:"uuid"`
+	OpenTime  datatypes.Time `json:"open_time"`
+	CloseTime datatypes.Time `json:"close_time"`
+	OwnerID   string         `json:"owner_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+func newCachedBusiness(b entity.Business) cachedBusiness {
+	return cachedBusiness{
+		Business:  b,
+		UUID:      b.UUID,
+		OpenTime:  b.OpenTime,
+		CloseTime: b.CloseTime,
+		OwnerID:   b.OwnerID,
+		CreatedAt: b.CreatedAt,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func (c cachedBusiness) business() entity.Business {
+	b := c.Business
+	b.UUID = c.UUID
+	b.OpenTime = c.OpenTime
+	b.CloseTime = c.CloseTime
+	b.OwnerID = c.OwnerID
+	b.CreatedAt = c.CreatedAt
+	b.UpdatedAt = c.UpdatedAt
+	return b
+}
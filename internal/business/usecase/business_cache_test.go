@@ -0,0 +1,203 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/entity"
+	"backend-test/pkg/cache"
+	"backend-test/pkg/logger"
+)
+
+// countingBusiness counts the reads and searches that reach the use case.
+type countingBusiness struct {
+	usecase.Business
+	reads, searches int
+}
+
+func (c *countingBusiness) Read(ctx context.Context, id string) (entity.Business, error) {
+	c.reads++
+	return c.Business.Read(ctx, id)
+}
+
+func (c *countingBusiness) Search(ctx context.Context, sp entity.SearchBusinessParam) ([]entity.Business, error) {
+	c.searches++
+	return c.Business.Search(ctx, sp)
+}
+
+func newCachedUseCase(t *testing.T) (*usecase.CachedBusiness, *countingBusiness, *repo.BusinessMemoryRepo) {
+	t.Helper()
+
+	bu, business := newUseCase(t)
+	counting := &countingBusiness{Business: bu}
+	return usecase.NewCachedBusiness(counting, cache.NewLRU(64), time.Minute, logger.New("error")), counting, business
+}
+
+func TestCachedBusinessRead(t *testing.T) {
+	cb, counting, business := newCachedUseCase(t)
+	seed(t, business, "b1", "user:1")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		b, err := cb.Read(ctx, "b1")
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if b.OwnerID != "user:1" {
+			t.Errorf("cached owner = %q, want user:1", b.OwnerID)
+		}
+	}
+	if counting.reads != 1 {
+		t.Errorf("reads reaching the use case = %d, want 1", counting.reads)
+	}
+
+	if _, err := cb.Read(ctx, "missing"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Read(missing) = %v, want ErrNotFound", err)
+	}
+
+	editor := as(entity.NewUserPrincipal("2", []string{entity.RoleEditor}))
+	if err := cb.Update(editor, "b1", entity.Business{Name: "renamed"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	b, err := cb.Read(ctx, "b1")
+	if err != nil {
+		t.Fatalf("Read after Update: %v", err)
+	}
+	if b.Name != "renamed" {
+		t.Errorf("name after Update = %q, want renamed", b.Name)
+	}
+
+	admin := as(entity.NewUserPrincipal("1", []string{entity.RoleAdmin}))
+	if err := cb.Delete(admin, "b1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := cb.Read(ctx, "b1"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Read after Delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCachedBusinessSearch(t *testing.T) {
+	cb, counting, business := newCachedUseCase(t)
+	seed(t, business, "b1", "")
+	ctx := context.Background()
+
+	equivalent := []entity.SearchBusinessParam{
+		{Limit: 100, Attributes: []string{"wifi", "parking"}},
+		{Limit: 500, Attributes: []string{"parking", "wifi", "wifi"}},
+		{Limit: 100, Attributes: []string{"parking", "wifi"}, Latitude: 1, Longitude: 2},
+	}
+	for _, sp := range equivalent {
+		if _, err := cb.Search(ctx, sp); err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+	}
+	if counting.searches != 1 {
+		t.Errorf("equivalent searches reaching the use case = %d, want 1", counting.searches)
+	}
+
+	if _, err := cb.Search(ctx, entity.SearchBusinessParam{Limit: 100, Attributes: []string{"wifi,parking"}}); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if counting.searches != 2 {
+		t.Errorf("searches after a distinct one = %d, want 2", counting.searches)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cb.Search(ctx, entity.SearchBusinessParam{Limit: 100, OpenNow: true}); err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+	}
+	if counting.searches != 4 {
+		t.Errorf("searches after two open_now ones = %d, want 4", counting.searches)
+	}
+
+	all := entity.SearchBusinessParam{Limit: 10}
+	before, err := cb.Search(ctx, all)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	editor := as(entity.NewUserPrincipal("2", []string{entity.RoleEditor}))
+	if err := cb.Create(editor, entity.Business{Alias: "b2", Name: "b2", Price: "$"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	after, err := cb.Search(ctx, all)
+	if err != nil {
+		t.Fatalf("Search after Create: %v", err)
+	}
+	if len(after) != len(before)+1 {
+		t.Errorf("results after Create = %d, want %d", len(after), len(before)+1)
+	}
+}
+
+func TestCachedBusinessClaimApproval(t *testing.T) {
+	l := logger.New("error")
+	business := repo.NewBusinessMemoryRepo(l)
+	audit := repo.NewAuditMemoryRepo()
+	claims := repo.NewClaimMemoryRepo()
+	uow := repo.NewMemoryUnitOfWork(business, audit, claims)
+
+	cb := usecase.NewCachedBusiness(usecase.NewBusinessUseCase(business, audit, uow, l), cache.NewLRU(64), time.Minute, l)
+	cu := usecase.NewClaimUseCase(claims, business, audit, uow, cb, l)
+	seed(t, business, "b1", "")
+
+	if _, err := cb.Read(context.Background(), "b1"); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	c, err := cu.Submit(as(entity.NewUserPrincipal("2", nil)), "b1", "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := cu.Approve(as(entity.NewUserPrincipal("1", []string{entity.RoleAdmin})), c.ID); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+
+	b, err := cb.Read(context.Background(), "b1")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if b.OwnerID != "user:2" {
+		t.Errorf("owner after approval = %q, want user:2", b.OwnerID)
+	}
+}
+
+func TestCachedBusinessFieldset(t *testing.T) {
+	cb, counting, business := newCachedUseCase(t)
+	if err := business.Create(context.Background(), entity.Business{
+		ID: "b1", Alias: "b1", Price: "$",
+		Categories: []entity.Categories{{Alias: "fnb"}},
+	}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	bare := entity.WithFieldset(context.Background(), entity.Fieldset{"id", "name"})
+	b, err := cb.Read(bare, "b1")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(b.Categories) != 0 {
+		t.Errorf("categories of a read without them = %+v, want none", b.Categories)
+	}
+
+	b, err = cb.Read(context.Background(), "b1")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(b.Categories) != 1 {
+		t.Errorf("categories of a full read after a bare one = %+v, want fnb", b.Categories)
+	}
+	if counting.reads != 2 {
+		t.Errorf("reads reaching the use case = %d, want 2", counting.reads)
+	}
+
+	editor := as(entity.NewUserPrincipal("2", []string{entity.RoleEditor}))
+	if err := cb.Update(editor, "b1", entity.Business{Name: "renamed"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if b, _ = cb.Read(bare, "b1"); b.Name != "renamed" {
+		t.Errorf("name of a bare read after Update = %q, want renamed", b.Name)
+	}
+}
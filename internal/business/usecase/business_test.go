@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/datatypes"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+func newUseCase(t *testing.T) (*usecase.BusinessUseCase, *repo.BusinessMemoryRepo) {
+	t.Helper()
+
+	l := logger.New("error")
+	business := repo.NewBusinessMemoryRepo(l)
+	audit := repo.NewAuditMemoryRepo()
+
+	return usecase.NewBusinessUseCase(business, audit, repo.NewMemoryUnitOfWork(business, audit), l), business
+}
+
+func seed(t *testing.T, r *repo.BusinessMemoryRepo, id, owner string) {
+	t.Helper()
+
+	err := r.Create(context.Background(), entity.Business{
+		ID:           id,
+		Alias:        id,
+		Price:        "$",
+		OwnerID:      owner,
+		Attributes:   datatypes.JSONType[[]string]{Data: []string{}},
+		Transactions: datatypes.JSONType[[]string]{Data: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+}
+
+func as(p entity.Principal) context.Context {
+	return entity.WithPrincipal(context.Background(), p)
+}
+
+func TestBusinessRoles(t *testing.T) {
+	admin := entity.NewUserPrincipal("1", []string{entity.RoleAdmin})
+	editor := entity.NewUserPrincipal("2", []string{entity.RoleEditor})
+	owner := entity.NewUserPrincipal("3", nil)
+	stranger := entity.NewUserPrincipal("4", nil)
+	writeKey := entity.Principal{Subject: entity.APIKeySubject(1), Scopes: []string{entity.ScopeWrite}}
+
+	tests := []struct {
+		name string
+		run  func(bu *usecase.BusinessUseCase) error
+		want error
+	}{
+		{"AnonymousUpdate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(context.Background(), "b1", entity.Business{Name: "x"})
+		}, entity.ErrUnauthenticated},
+		{"EditorUpdate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(as(editor), "b1", entity.Business{Name: "x"})
+		}, nil},
+		{"WriteKeyUpdate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(as(writeKey), "b1", entity.Business{Name: "x"})
+		}, nil},
+		{"OwnerUpdate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(as(owner), "b1", entity.Business{Name: "x"})
+		}, nil},
+		{"StrangerUpdate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(as(stranger), "b1", entity.Business{Name: "x"})
+		}, entity.ErrForbidden},
+		{"StrangerUpdateUnowned", func(bu *usecase.BusinessUseCase) error {
+			return bu.Update(as(stranger), "b2", entity.Business{Name: "x"})
+		}, entity.ErrForbidden},
+		{"EditorDelete", func(bu *usecase.BusinessUseCase) error {
+			return bu.Delete(as(editor), "b1")
+		}, entity.ErrForbidden},
+		{"WriteKeyDelete", func(bu *usecase.BusinessUseCase) error {
+			return bu.Delete(as(writeKey), "b1")
+		}, entity.ErrForbidden},
+		{"AdminDelete", func(bu *usecase.BusinessUseCase) error {
+			return bu.Delete(as(admin), "b1")
+		}, nil},
+		{"OwnerCreate", func(bu *usecase.BusinessUseCase) error {
+			return bu.Create(as(owner), entity.Business{Alias: "new", Price: "$"})
+		}, entity.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bu, r := newUseCase(t)
+			seed(t, r, "b1", owner.Subject)
+			seed(t, r, "b2", "")
+
+			if err := tt.run(bu); !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
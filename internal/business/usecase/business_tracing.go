@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"backend-test/internal/entity"
+)
+
+const _tracerName = "backend-test/internal/business/usecase"
+
+// TracedBusiness wraps a Business with one span per method.
+type TracedBusiness struct {
+	next   Business
+	tracer trace.Tracer
+}
+
+var _ Business = (*TracedBusiness)(nil)
+
+// NewTracedBusiness -.
+func NewTracedBusiness(next Business) *TracedBusiness {
+	return &TracedBusiness{
+		next:   next,
+		tracer: otel.Tracer(_tracerName),
+	}
+}
+
+func (tb *TracedBusiness) Create(ctx context.Context, b entity.Business) error {
+	ctx, span := tb.tracer.Start(ctx, "BusinessUseCase.Create", trace.WithAttributes(attribute.String("business.alias", b.Alias)))
+	defer span.End()
+
+	return end(span, tb.next.Create(ctx, b))
+}
+
+func (tb *TracedBusiness) Read(ctx context.Context, id string) (entity.Business, error) {
+	ctx, span := tb.tracer.Start(ctx, "BusinessUseCase.Read", trace.WithAttributes(attribute.String("business.id", id)))
+	defer span.End()
+
+	business, err := tb.next.Read(ctx, id)
+	return business, end(span, err)
+}
+
+func (tb *TracedBusiness) Search(ctx context.Context, sp entity.SearchBusinessParam) ([]entity.Business, error) {
+	ctx, span := tb.tracer.Start(ctx, "BusinessUseCase.Search", trace.WithAttributes(
+		attribute.Int("search.limit", int(sp.Limit)),
+		attribute.Int("search.offset", int(sp.Offset)),
+		attribute.StringSlice("search.categories", sp.Categories),
+		attribute.StringSlice("search.attributes", sp.Attributes),
+		attribute.Float64("search.radius", sp.Radius),
+	))
+	defer span.End()
+
+	businesses, err := tb.next.Search(ctx, sp)
+	span.SetAttributes(attribute.Int("search.results", len(businesses)))
+	return businesses, end(span, err)
+}
+
+func (tb *TracedBusiness) Update(ctx context.Context, id string, b entity.Business) error {
+	ctx, span := tb.tracer.Start(ctx, "BusinessUseCase.Update", trace.WithAttributes(attribute.String("business.id", id)))
+	defer span.End()
+
+	return end(span, tb.next.Update(ctx, id, b))
+}
+
+func (tb *TracedBusiness) Delete(ctx context.Context, id string) error {
+	ctx, span := tb.tracer.Start(ctx, "BusinessUseCase.Delete", trace.WithAttributes(attribute.String("business.id", id)))
+	defer span.End()
+
+	return end(span, tb.next.Delete(ctx, id))
+}
+
+// end marks span as failed when err is set and passes err through.
+func end(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
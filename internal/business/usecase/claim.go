@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+var (
+	// ErrClaimReviewed is returned when approving or rejecting a claim that is
+	// no longer pending.
+	ErrClaimReviewed = entity.ErrClaimReviewed
+	// ErrInvalidClaimStatus is returned when listing claims by an unknown status.
+	ErrInvalidClaimStatus = errors.New("status must be pending, approved or rejected")
+)
+
+// ClaimUseCase -.
+type ClaimUseCase struct {
+	claims   ClaimRepo
+	business BusinessRepo
+	audit    AuditRepo
+	uow      UnitOfWork
+	cache    Invalidator
+	l        logger.Interface
+}
+
+// NewClaimUseCase -. cache, which may be nil, is told about ownership changes.
+func NewClaimUseCase(c ClaimRepo, b BusinessRepo, a AuditRepo, uow UnitOfWork, cache Invalidator, l logger.Interface) *ClaimUseCase {
+	return &ClaimUseCase{
+		claims:   c,
+		business: b,
+		audit:    a,
+		uow:      uow,
+		cache:    cache,
+		l:        l,
+	}
+}
+
+// Submit files a claim by the caller. A caller may have one pending claim per
+// business and cannot claim a business they already own.
+func (cu *ClaimUseCase) Submit(ctx context.Context, businessID string, message string) (entity.BusinessClaim, error) {
+	p, ok := entity.PrincipalFrom(ctx)
+	if !ok {
+		return entity.BusinessClaim{}, entity.ErrUnauthenticated
+	}
+
+	var claim entity.BusinessClaim
+	err := cu.uow.Do(ctx, func(ctx context.Context) error {
+		b, err := cu.business.ReadById(ctx, businessID)
+		if err != nil {
+			return fmt.Errorf("business.ReadById: %w", err)
+		}
+		if b.OwnerID == p.Subject {
+			return fmt.Errorf("%w: %s already owns %s", entity.ErrAlreadyExists, p.Subject, businessID)
+		}
+
+		pending, err := cu.claims.List(ctx, businessID, entity.ClaimPending)
+		if err != nil {
+			return fmt.Errorf("claims.List: %w", err)
+		}
+		for _, c := range pending {
+			if c.Claimant == p.Subject {
+				return fmt.Errorf("%w: claim %d is pending", entity.ErrAlreadyExists, c.ID)
+			}
+		}
+
+		claim, err = cu.claims.Create(ctx, entity.BusinessClaim{
+			BusinessID: businessID,
+			Claimant:   p.Subject,
+			Message:    message,
+			Status:     entity.ClaimPending,
+		})
+		if err != nil {
+			return fmt.Errorf("claims.Create: %w", err)
+		}
+
+		return cu.record(ctx, entity.AuditClaimSubmit, businessID)
+	})
+	if err != nil {
+		cu.log(ctx).Error(fmt.Errorf("usecase - Submit - %w", err))
+		return entity.BusinessClaim{}, err
+	}
+
+	cu.log(ctx).Info("usecase - Submit - claim %d on %s by %s", claim.ID, businessID, p.Subject)
+	return claim, nil
+}
+
+// List -.
+func (cu *ClaimUseCase) List(ctx context.Context, status string) ([]entity.BusinessClaim, error) {
+	if err := requireRole(ctx, entity.RoleAdmin); err != nil {
+		cu.log(ctx).Warn("usecase - List - %s", err)
+		return nil, err
+	}
+
+	switch status {
+	case "", entity.ClaimPending, entity.ClaimApproved, entity.ClaimRejected:
+	default:
+		return nil, fmt.Errorf("usecase - List - %w, got %q", ErrInvalidClaimStatus, status)
+	}
+
+	claims, err := cu.claims.List(ctx, "", status)
+	if err != nil {
+		cu.log(ctx).Error(fmt.Errorf("usecase - List - claims.List: %w", err))
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Approve makes the claimant the owner of the business and rejects the other
+// pending claims on it, since a business has a single owner.
+func (cu *ClaimUseCase) Approve(ctx context.Context, id uint) (entity.BusinessClaim, error) {
+	claim, err := cu.review(ctx, id, entity.ClaimApproved, func(ctx context.Context, c entity.BusinessClaim, reviewer string, at time.Time) error {
+		if err := cu.business.SetOwner(ctx, c.BusinessID, c.Claimant); err != nil {
+			return fmt.Errorf("business.SetOwner: %w", err)
+		}
+
+		others, err := cu.claims.List(ctx, c.BusinessID, entity.ClaimPending)
+		if err != nil {
+			return fmt.Errorf("claims.List: %w", err)
+		}
+		for _, other := range others {
+			if other.ID == c.ID {
+				continue
+			}
+			if err := cu.claims.Review(ctx, other.ID, entity.ClaimRejected, reviewer, at); err != nil {
+				return fmt.Errorf("claims.Review: %w", err)
+			}
+		}
+
+		return cu.record(ctx, entity.AuditClaimApprove, c.BusinessID)
+	})
+	if err != nil {
+		cu.log(ctx).Error(fmt.Errorf("usecase - Approve - %w", err))
+		return entity.BusinessClaim{}, err
+	}
+
+	if cu.cache != nil {
+		cu.cache.Invalidate(ctx, claim.BusinessID)
+	}
+	cu.log(ctx).Info("usecase - Approve - %s now owns %s", claim.Claimant, claim.BusinessID)
+	return claim, nil
+}
+
+// Reject -.
+func (cu *ClaimUseCase) Reject(ctx context.Context, id uint) (entity.BusinessClaim, error) {
+	claim, err := cu.review(ctx, id, entity.ClaimRejected, func(ctx context.Context, c entity.BusinessClaim, _ string, _ time.Time) error {
+		return cu.record(ctx, entity.AuditClaimReject, c.BusinessID)
+	})
+	if err != nil {
+		cu.log(ctx).Error(fmt.Errorf("usecase - Reject - %w", err))
+		return entity.BusinessClaim{}, err
+	}
+	return claim, nil
+}
+
+// review moves a pending claim to status and runs then in the same unit of
+// work. Only admins review claims.
+func (cu *ClaimUseCase) review(ctx context.Context, id uint, status string,
+	then func(ctx context.Context, c entity.BusinessClaim, reviewer string, at time.Time) error,
+) (entity.BusinessClaim, error) {
+	if err := requireRole(ctx, entity.RoleAdmin); err != nil {
+		return entity.BusinessClaim{}, err
+	}
+	p, _ := entity.PrincipalFrom(ctx)
+
+	var claim entity.BusinessClaim
+	err := cu.uow.Do(ctx, func(ctx context.Context) error {
+		c, err := cu.claims.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("claims.Get: %w", err)
+		}
+		if c.Status != entity.ClaimPending {
+			return fmt.Errorf("%w: claim %d is %s", ErrClaimReviewed, c.ID, c.Status)
+		}
+
+		at := time.Now()
+		if err := cu.claims.Review(ctx, id, status, p.Subject, at); err != nil {
+			return fmt.Errorf("claims.Review: %w", err)
+		}
+		c.Status, c.ReviewedBy, c.ReviewedAt = status, p.Subject, &at
+		claim = c
+
+		return then(ctx, c, p.Subject, at)
+	})
+
+	return claim, err
+}
+
+// record writes an audit entry for the business a claim is about.
+func (cu *ClaimUseCase) record(ctx context.Context, action, businessID string) error {
+	entry := entity.AuditEntry{
+		Action:   action,
+		Entity:   "business",
+		EntityID: businessID,
+	}
+	if p, ok := entity.PrincipalFrom(ctx); ok {
+		entry.Actor = p.Subject
+	}
+	if err := cu.audit.Record(ctx, entry); err != nil {
+		return fmt.Errorf("audit.Record: %w", err)
+	}
+	return nil
+}
+
+// log returns the logger of the request in ctx, falling back to cu.l.
+func (cu *ClaimUseCase) log(ctx context.Context) logger.Interface {
+	return logger.FromContext(ctx, cu.l)
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+func TestClaimFlow(t *testing.T) {
+	l := logger.New("error")
+	business := repo.NewBusinessMemoryRepo(l)
+	audit := repo.NewAuditMemoryRepo()
+	claims := repo.NewClaimMemoryRepo()
+	uow := repo.NewMemoryUnitOfWork(business, audit, claims)
+
+	bu := usecase.NewBusinessUseCase(business, audit, uow, l)
+	cu := usecase.NewClaimUseCase(claims, business, audit, uow, nil, l)
+	seed(t, business, "b1", "")
+
+	admin := as(entity.NewUserPrincipal("1", []string{entity.RoleAdmin}))
+	alice := as(entity.NewUserPrincipal("2", nil))
+	bob := as(entity.NewUserPrincipal("3", nil))
+
+	if _, err := cu.Submit(context.Background(), "b1", ""); !errors.Is(err, entity.ErrUnauthenticated) {
+		t.Fatalf("anonymous Submit = %v, want ErrUnauthenticated", err)
+	}
+	if _, err := cu.Submit(alice, "missing", ""); !errors.Is(err, entity.ErrNotFound) {
+		t.Fatalf("Submit on a missing business = %v, want ErrNotFound", err)
+	}
+
+	mine, err := cu.Submit(alice, "b1", "I own it")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if _, err := cu.Submit(alice, "b1", "again"); !errors.Is(err, entity.ErrAlreadyExists) {
+		t.Fatalf("second pending Submit = %v, want ErrAlreadyExists", err)
+	}
+	theirs, err := cu.Submit(bob, "b1", "No, I do")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	if err := bu.Update(alice, "b1", entity.Business{Name: "x"}); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("Update before approval = %v, want ErrForbidden", err)
+	}
+	if _, err := cu.Approve(alice, mine.ID); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("Approve by the claimant = %v, want ErrForbidden", err)
+	}
+	if _, err := cu.List(bob, ""); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("List by a user = %v, want ErrForbidden", err)
+	}
+
+	approved, err := cu.Approve(admin, mine.ID)
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if approved.Status != entity.ClaimApproved || approved.ReviewedBy != "user:1" {
+		t.Errorf("Approve = %+v, want approved by user:1", approved)
+	}
+
+	b, err := business.ReadById(context.Background(), "b1")
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if b.OwnerID != "user:2" {
+		t.Errorf("owner = %q, want user:2", b.OwnerID)
+	}
+	if err := bu.Update(alice, "b1", entity.Business{Name: "x"}); err != nil {
+		t.Errorf("Update by the new owner: %v", err)
+	}
+	if err := bu.Update(bob, "b1", entity.Business{Name: "y"}); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("Update by the other claimant = %v, want ErrForbidden", err)
+	}
+
+	if _, err := cu.Approve(admin, theirs.ID); !errors.Is(err, usecase.ErrClaimReviewed) {
+		t.Errorf("Approve of the competing claim = %v, want ErrClaimReviewed", err)
+	}
+	rejected, err := cu.List(admin, entity.ClaimRejected)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(rejected) != 1 || rejected[0].ID != theirs.ID {
+		t.Errorf("rejected claims = %+v, want the competing claim", rejected)
+	}
+	if _, err := cu.Submit(alice, "b1", ""); !errors.Is(err, entity.ErrAlreadyExists) {
+		t.Errorf("Submit by the owner = %v, want ErrAlreadyExists", err)
+	}
+	if _, err := cu.List(admin, "bogus"); !errors.Is(err, usecase.ErrInvalidClaimStatus) {
+		t.Errorf("List(bogus) = %v, want ErrInvalidClaimStatus", err)
+	}
+}
+
+func TestClaimRejectKeepsOwner(t *testing.T) {
+	l := logger.New("error")
+	business := repo.NewBusinessMemoryRepo(l)
+	audit := repo.NewAuditMemoryRepo()
+	claims := repo.NewClaimMemoryRepo()
+	cu := usecase.NewClaimUseCase(claims, business, audit, repo.NewMemoryUnitOfWork(business, audit, claims), nil, l)
+	seed(t, business, "b1", "user:5")
+
+	c, err := cu.Submit(as(entity.NewUserPrincipal("2", nil)), "b1", "")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	got, err := cu.Reject(as(entity.NewUserPrincipal("1", []string{entity.RoleAdmin})), c.ID)
+	if err != nil {
+		t.Fatalf("Reject: %v", err)
+	}
+	if got.Status != entity.ClaimRejected {
+		t.Errorf("status = %q, want rejected", got.Status)
+	}
+
+	b, _ := business.ReadById(context.Background(), "b1")
+	if b.OwnerID != "user:5" {
+		t.Errorf("owner = %q, want user:5", b.OwnerID)
+	}
+	entries, _ := audit.List(context.Background(), "business", "b1")
+	if len(entries) != 2 || entries[1].Action != entity.AuditClaimReject {
+		t.Errorf("audit = %+v, want a submit and a reject entry", entries)
+	}
+}
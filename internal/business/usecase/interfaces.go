@@ -8,8 +8,6 @@ import (
 	"backend-test/internal/entity"
 )
 
-//go:generate mockgen -source=interfaces.go -destination=./mocks_test.go -package=usecase_test
-
 type (
 	// Business -.
 	Business interface {
@@ -25,7 +23,73 @@ type (
 		Create(context.Context, entity.Business) error
 		ReadById(context.Context, string) (entity.Business, error)
 		UpdateById(context.Context, string, entity.Business) error
+		// SetOwner records ownerID as the owner of business id; an empty
+		// ownerID clears it.
+		SetOwner(ctx context.Context, id string, ownerID string) error
 		DeleteById(context.Context, string) error
-		Search(ctx context.Context, limit uint, offset uint, price uint, attributes []string, categories []string, openAt time.Time) ([]entity.Business, error)
+		Search(ctx context.Context, limit uint, offset uint, price uint, attributes []string, categories []string, openAt time.Time, near entity.GeoFilter) ([]entity.Business, error)
+	}
+
+	// APIKey issues, revokes and checks API keys.
+	APIKey interface {
+		// Issue creates a key and returns it with its plaintext, which is not
+		// stored and cannot be retrieved later.
+		Issue(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error)
+		Revoke(ctx context.Context, id uint) error
+		List(ctx context.Context) ([]entity.APIKey, error)
+		// Authenticate returns the principal of a live key, or
+		// entity.ErrUnauthenticated.
+		Authenticate(ctx context.Context, key string) (entity.Principal, error)
+	}
+
+	// APIKeyRepo -.
+	APIKeyRepo interface {
+		Create(context.Context, entity.APIKey) (entity.APIKey, error)
+		FindByHash(context.Context, string) (entity.APIKey, error)
+		List(context.Context) ([]entity.APIKey, error)
+		Revoke(ctx context.Context, id uint, at time.Time) error
+	}
+
+	// Invalidator drops cached copies of a business changed outside the
+	// Business use case, or of every business when id is empty.
+	Invalidator interface {
+		Invalidate(ctx context.Context, id string)
+	}
+
+	// Claim handles requests by users to own a business.
+	Claim interface {
+		// Submit files a claim on a business by the caller in ctx.
+		Submit(ctx context.Context, businessID string, message string) (entity.BusinessClaim, error)
+		// List returns the claims in status, or every claim when status is
+		// empty. Admins only.
+		List(ctx context.Context, status string) ([]entity.BusinessClaim, error)
+		// Approve makes the claimant the owner of the business. Admins only.
+		Approve(ctx context.Context, id uint) (entity.BusinessClaim, error)
+		// Reject closes a claim without changing ownership. Admins only.
+		Reject(ctx context.Context, id uint) (entity.BusinessClaim, error)
+	}
+
+	// ClaimRepo -.
+	ClaimRepo interface {
+		Create(context.Context, entity.BusinessClaim) (entity.BusinessClaim, error)
+		Get(context.Context, uint) (entity.BusinessClaim, error)
+		// List filters on businessID and status when they are not empty,
+		// oldest first.
+		List(ctx context.Context, businessID string, status string) ([]entity.BusinessClaim, error)
+		// Review moves a pending claim to status, recording who reviewed it
+		// and when. It returns entity.ErrClaimReviewed when the claim is no
+		// longer pending, so concurrent reviews cannot both succeed.
+		Review(ctx context.Context, id uint, status string, reviewer string, at time.Time) error
+	}
+
+	// AuditRepo -.
+	AuditRepo interface {
+		Record(context.Context, entity.AuditEntry) error
+	}
+
+	// UnitOfWork runs fn atomically: every repository call made with the ctx
+	// passed to fn commits when fn returns nil and rolls back otherwise.
+	UnitOfWork interface {
+		Do(ctx context.Context, fn func(ctx context.Context) error) error
 	}
 )
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend-test/internal/db/instrument"
+	"backend-test/internal/entity"
+)
+
+// APIKeyRepo -.
+type APIKeyRepo struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepo -.
+func NewAPIKeyRepo(db *gorm.DB) *APIKeyRepo {
+	return &APIKeyRepo{db: db}
+}
+
+// Create -.
+func (kr *APIKeyRepo) Create(ctx context.Context, k entity.APIKey) (entity.APIKey, error) {
+	ctx = instrument.WithOperation(ctx, "api_key_create")
+
+	if err := conn(ctx, kr.db).Create(&k).Error; err != nil {
+		return k, translateError(ctx, err)
+	}
+	return k, nil
+}
+
+// FindByHash -.
+func (kr *APIKeyRepo) FindByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	ctx = instrument.WithOperation(ctx, "api_key_read")
+
+	var k entity.APIKey
+	if err := conn(ctx, kr.db).Where("hash = ?", hash).First(&k).Error; err != nil {
+		return k, translateError(ctx, err)
+	}
+	return k, nil
+}
+
+// List returns every key, revoked ones included, oldest first.
+func (kr *APIKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	ctx = instrument.WithOperation(ctx, "api_key_list")
+
+	var keys []entity.APIKey
+	if err := conn(ctx, kr.db).Order("id").Find(&keys).Error; err != nil {
+		return nil, translateError(ctx, err)
+	}
+	return keys, nil
+}
+
+// Revoke marks a key revoked. Revoking a revoked key keeps its original time.
+func (kr *APIKeyRepo) Revoke(ctx context.Context, id uint, at time.Time) error {
+	ctx = instrument.WithOperation(ctx, "api_key_revoke")
+
+	err := transaction(ctx, kr.db, func(tx *gorm.DB) error {
+		var k entity.APIKey
+		if err := tx.Where("id = ?", id).First(&k).Error; err != nil {
+			return err
+		}
+		if k.Revoked() {
+			return nil
+		}
+
+		return tx.Model(&k).Update("revoked_at", at).Error
+	})
+	if err != nil {
+		return translateError(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend-test/internal/entity"
+)
+
+// APIKeyMemoryRepo -.
+type APIKeyMemoryRepo struct {
+	mu   sync.RWMutex
+	keys []entity.APIKey
+}
+
+// NewAPIKeyMemoryRepo -.
+func NewAPIKeyMemoryRepo() *APIKeyMemoryRepo {
+	return &APIKeyMemoryRepo{}
+}
+
+// Create -.
+func (kr *APIKeyMemoryRepo) Create(ctx context.Context, k entity.APIKey) (entity.APIKey, error) {
+	if err := contextError(ctx); err != nil {
+		return k, err
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	for _, existing := range kr.keys {
+		if existing.Hash == k.Hash {
+			return k, entity.ErrAlreadyExists
+		}
+	}
+
+	k.ID = uint(len(kr.keys) + 1)
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now()
+	}
+	kr.keys = append(kr.keys, k)
+
+	return k, nil
+}
+
+// FindByHash -.
+func (kr *APIKeyMemoryRepo) FindByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	if err := contextError(ctx); err != nil {
+		return entity.APIKey{}, err
+	}
+
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+
+	for _, k := range kr.keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+
+	return entity.APIKey{}, entity.ErrNotFound
+}
+
+// List returns every key, revoked ones included, oldest first.
+func (kr *APIKeyMemoryRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+
+	return append([]entity.APIKey(nil), kr.keys...), nil
+}
+
+// Revoke marks a key revoked. Revoking a revoked key keeps its original time.
+func (kr *APIKeyMemoryRepo) Revoke(ctx context.Context, id uint, at time.Time) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+
+	for i := range kr.keys {
+		if kr.keys[i].ID == id {
+			if !kr.keys[i].Revoked() {
+				kr.keys[i].RevokedAt = &at
+			}
+			return nil
+		}
+	}
+
+	return entity.ErrNotFound
+}
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"backend-test/internal/db/instrument"
+	"backend-test/internal/entity"
+)
+
+// AuditRepo -.
+type AuditRepo struct {
+	db *gorm.DB
+}
+
+// NewAuditRepo -.
+func NewAuditRepo(db *gorm.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+// Record -.
+func (ar *AuditRepo) Record(ctx context.Context, e entity.AuditEntry) error {
+	ctx = instrument.WithOperation(ctx, "audit")
+
+	if err := conn(ctx, ar.db).Create(&e).Error; err != nil {
+		return translateError(ctx, err)
+	}
+	return nil
+}
+
+// List returns the audit entries for one entity, oldest first.
+func (ar *AuditRepo) List(ctx context.Context, entityName, entityID string) ([]entity.AuditEntry, error) {
+	var entries []entity.AuditEntry
+
+	result := conn(ctx, ar.db).Where("entity = ? AND entity_id = ?", entityName, entityID).Order("id").Find(&entries)
+	if result.Error != nil {
+		return nil, translateError(ctx, result.Error)
+	}
+
+	return entries, nil
+}
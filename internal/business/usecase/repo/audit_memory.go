@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend-test/internal/entity"
+)
+
+// AuditMemoryRepo -.
+type AuditMemoryRepo struct {
+	mu      sync.RWMutex
+	entries []entity.AuditEntry
+}
+
+// NewAuditMemoryRepo -.
+func NewAuditMemoryRepo() *AuditMemoryRepo {
+	return &AuditMemoryRepo{}
+}
+
+// Record -.
+func (ar *AuditMemoryRepo) Record(ctx context.Context, e entity.AuditEntry) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+
+	e.ID = uint(len(ar.entries) + 1)
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	ar.entries = append(ar.entries, e)
+
+	return nil
+}
+
+// List returns the audit entries for one entity, oldest first.
+func (ar *AuditMemoryRepo) List(ctx context.Context, entityName, entityID string) ([]entity.AuditEntry, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+
+	var entries []entity.AuditEntry
+	for _, e := range ar.entries {
+		if e.Entity == entityName && e.EntityID == entityID {
+			entries = append(entries, e)
+		}
+	}
+
+	return entries, nil
+}
+
+func (ar *AuditMemoryRepo) snapshot() func() {
+	ar.mu.RLock()
+	saved := append([]entity.AuditEntry(nil), ar.entries...)
+	ar.mu.RUnlock()
+
+	return func() {
+		ar.mu.Lock()
+		ar.entries = saved
+		ar.mu.Unlock()
+	}
+}
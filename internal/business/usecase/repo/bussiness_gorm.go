@@ -0,0 +1,273 @@
+package repo
+
+import (
+	"backend-test/internal/db/instrument"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BusinessRepo -.
+type BusinessRepo struct {
+	db       *gorm.DB
+	l        logger.Interface
+	timeouts Timeouts
+}
+
+// NewBusinessRepo -.
+func NewBusinessRepo(db *gorm.DB, l logger.Interface, opts ...Option) *BusinessRepo {
+	br := &BusinessRepo{
+		db: db,
+		l:  l,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(br)
+	}
+
+	return br
+}
+
+func (br *BusinessRepo) Create(ctx context.Context, b entity.Business) error {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "create"), br.timeouts.Create)
+	defer cancel()
+
+	err := transaction(ctx, br.db, func(tx *gorm.DB) error {
+		cats, err := findCategories(tx, b.Categories)
+		if err != nil {
+			return err
+		}
+
+		bWithoutCat := b
+		bWithoutCat.Categories = nil
+
+		if result := tx.Create(&bWithoutCat); result.Error != nil {
+			return result.Error
+		}
+
+		if len(cats) == 0 {
+			return nil
+		}
+
+		return tx.Model(&bWithoutCat).Association("Categories").Append(&cats)
+	})
+	if err != nil {
+		return translateError(ctx, err)
+	}
+
+	return nil
+}
+func (br *BusinessRepo) ReadById(ctx context.Context, id string) (entity.Business, error) {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "read"), br.timeouts.Read)
+	defer cancel()
+
+	var business entity.Business
+
+	result := preloadCategories(ctx, conn(ctx, br.db).Model(&entity.Business{})).Where("id=?", id).First(&business)
+	if result.Error != nil {
+		return business, translateError(ctx, result.Error)
+	}
+
+	return business, nil
+}
+func (br *BusinessRepo) UpdateById(ctx context.Context, id string, b entity.Business) error {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "update"), br.timeouts.Update)
+	defer cancel()
+
+	err := transaction(ctx, br.db, func(tx *gorm.DB) error {
+		business := &entity.Business{}
+
+		if result := tx.Where("ID = ?", id).Find(&business); result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected == 0 {
+			return entity.ErrNotFound
+		}
+
+		cats, err := findCategories(tx, b.Categories)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&business).Association("Categories").Replace(&cats); err != nil {
+			return err
+		}
+
+		b.Categories = nil
+
+		return tx.Model(&business).Where("ID = ?", id).Updates(&b).Error
+	})
+	if err != nil {
+		return translateError(ctx, err)
+	}
+
+	return nil
+}
+
+// SetOwner -.
+func (br *BusinessRepo) SetOwner(ctx context.Context, id string, ownerID string) error {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "set_owner"), br.timeouts.Update)
+	defer cancel()
+
+	result := conn(ctx, br.db).Model(&entity.Business{}).Where("id = ?", id).Update("owner_id", ownerID)
+	if result.Error != nil {
+		return translateError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (br *BusinessRepo) DeleteById(ctx context.Context, id string) error {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "delete"), br.timeouts.Delete)
+	defer cancel()
+
+	result := conn(ctx, br.db).Where("id=?", id).Delete(&entity.Business{})
+	if result.Error != nil {
+		return translateError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+func (br *BusinessRepo) Search(ctx context.Context, limit uint, offset uint, price uint, attributes []string, categories []string, openAt time.Time, near entity.GeoFilter) ([]entity.Business, error) {
+	ctx, cancel := withTimeout(instrument.WithOperation(ctx, "search"), br.timeouts.Search)
+	defer cancel()
+
+	var businesses []entity.Business
+	tx := preloadCategories(ctx, conn(ctx, br.db).Model(&entity.Business{}))
+	d := dialectOf(br.db)
+
+	if price != 0 {
+		tx = tx.Where(d.priceLength()+" = ?", price)
+	}
+
+	if len(attributes) > 0 {
+		tx = tx.Where(d.jsonArrayContains("attributes", attributes))
+	}
+
+	if len(categories) > 0 {
+		// tx = tx.Where("uuid IN (SELECT business_uuid FROM business_categories WHERE alias IN ?)", categories)
+		tx = tx.Where("uuid IN (?)", conn(ctx, br.db).Table("business_categories").
+			Joins("left join categories c on categories_id = c.id ").
+			Select("business_uuid").
+			Where("alias IN ?", categories),
+		)
+	}
+
+	if !openAt.IsZero() {
+		layoutTime := "15:04:05"
+		timeWithoutDate := openAt.Format(layoutTime)
+		tx = tx.Where("open_time < ? AND close_time > ?", timeWithoutDate, timeWithoutDate)
+	}
+
+	if near.Enabled() {
+		tx = tx.Where(d.withinRadius(near))
+	}
+
+	res := tx.Order("uuid").Limit(int(limit)).Offset(int(offset)).Find(&businesses)
+	if res.Error != nil {
+		return businesses, translateError(ctx, res.Error)
+	}
+
+	if near.Enabled() {
+		for i := range businesses {
+			businesses[i].Distance = near.Center.DistanceTo(businesses[i].Coordinates)
+		}
+	}
+
+	return businesses, nil
+}
+
+// List returns businesses ordered by primary key, including their categories.
+func (br *BusinessRepo) List(ctx context.Context, limit, offset int) ([]entity.Business, error) {
+	ctx = instrument.WithOperation(ctx, "list")
+
+	var businesses []entity.Business
+
+	result := br.db.WithContext(ctx).Model(&entity.Business{}).Preload("Categories").
+		Order("uuid").Limit(limit).Offset(offset).Find(&businesses)
+	if result.Error != nil {
+		return businesses, result.Error
+	}
+
+	return businesses, nil
+}
+
+// PurgeDeleted permanently removes businesses soft-deleted before the given time,
+// together with their category links.
+func (br *BusinessRepo) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ctx = instrument.WithOperation(ctx, "purge")
+
+	var purged int64
+
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Unscoped().Model(&entity.Business{}).
+			Select("uuid").
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+
+		if err := tx.Where("business_uuid IN (?)", deleted).Delete(&entity.BusinessCategories{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&entity.Business{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		purged = result.RowsAffected
+		return nil
+	})
+
+	return purged, err
+}
+
+// NormalizeRatings brings stored ratings back into a consistent state: a
+// business without reviews has no rating, and ratings are clamped to 0..5.
+// Reviews are not stored, so ratings cannot be computed from them.
+func (br *BusinessRepo) NormalizeRatings(ctx context.Context) (int64, error) {
+	ctx = instrument.WithOperation(ctx, "normalize_ratings")
+
+	result := br.db.WithContext(ctx).Model(&entity.Business{}).
+		Where("(review_count <= 0 AND rating <> 0) OR rating < 0 OR rating > 5").
+		Update("rating", gorm.Expr("CASE WHEN review_count <= 0 THEN 0 WHEN rating < 0 THEN 0 ELSE 5 END"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// preloadCategories loads the categories of the businesses tx finds, unless
+// the fieldset in ctx leaves them out.
+func preloadCategories(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if !entity.FieldsetFrom(ctx).Has("categories") {
+		return tx
+	}
+	return tx.Preload("Categories")
+}
+
+// findCategories resolves categories by alias. Unknown aliases are ignored.
+func findCategories(db *gorm.DB, cats []entity.Categories) ([]entity.Categories, error) {
+	if len(cats) == 0 {
+		return nil, nil
+	}
+
+	aliases := make([]string, 0, len(cats))
+	for _, c := range cats {
+		aliases = append(aliases, c.Alias)
+	}
+
+	var found []entity.Categories
+	if result := db.Where("alias IN ?", aliases).Find(&found); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return found, nil
+}
@@ -0,0 +1,135 @@
+package repo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/business/usecase/repo/repotest"
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/internal/db/migrate"
+	"backend-test/pkg/logger"
+)
+
+func TestBusinessRepoSqlite(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.BusinessRepo {
+		return repo.NewBusinessRepo(openSqlite(t), logger.New("error"))
+	})
+}
+
+func TestUnitOfWorkSqlite(t *testing.T) {
+	repotest.RunUnitOfWork(t, func(t *testing.T) repotest.Store {
+		return gormStore(openSqlite(t))
+	})
+}
+
+func TestAPIKeyRepoSqlite(t *testing.T) {
+	repotest.RunAPIKeys(t, func(t *testing.T) usecase.APIKeyRepo {
+		return repo.NewAPIKeyRepo(openSqlite(t))
+	})
+}
+
+func TestClaimRepoSqlite(t *testing.T) {
+	repotest.RunClaims(t, func(t *testing.T) usecase.ClaimRepo {
+		return repo.NewClaimRepo(openSqlite(t))
+	})
+}
+
+// TestBusinessRepoMysql is skipped unless MYSQL_TEST_DSN points at a disposable database.
+func TestBusinessRepoMysql(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set")
+	}
+
+	runShared(t, mysql.Open(dsn))
+}
+
+// TestBusinessRepoPostgres is skipped unless POSTGRES_TEST_DSN points at a
+// disposable database with PostGIS available.
+func TestBusinessRepoPostgres(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN not set")
+	}
+
+	runShared(t, postgres.Open(dsn))
+}
+
+// runShared runs the suite against one external database, emptying it between tests.
+func runShared(t *testing.T, dialector gorm.Dialector) {
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("gorm.Open: %v", err)
+	}
+
+	migrateUp(t, db)
+
+	truncate := func(t *testing.T) {
+		for _, table := range []string{"business_categories", "businesses", "audit_log", "api_keys", "business_claims"} {
+			if err := db.Exec("DELETE FROM " + table).Error; err != nil {
+				t.Fatalf("truncate %s: %v", table, err)
+			}
+		}
+	}
+
+	repotest.Run(t, func(t *testing.T) usecase.BusinessRepo {
+		truncate(t)
+		return repo.NewBusinessRepo(db, logger.New("error"))
+	})
+
+	repotest.RunUnitOfWork(t, func(t *testing.T) repotest.Store {
+		truncate(t)
+		return gormStore(db)
+	})
+
+	repotest.RunAPIKeys(t, func(t *testing.T) usecase.APIKeyRepo {
+		truncate(t)
+		return repo.NewAPIKeyRepo(db)
+	})
+
+	repotest.RunClaims(t, func(t *testing.T) usecase.ClaimRepo {
+		truncate(t)
+		return repo.NewClaimRepo(db)
+	})
+}
+
+func gormStore(db *gorm.DB) repotest.Store {
+	return repotest.Store{
+		Business: repo.NewBusinessRepo(db, logger.New("error")),
+		Audit:    repo.NewAuditRepo(db),
+		UoW:      repo.NewGormUnitOfWork(db),
+	}
+}
+
+func openSqlite(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+
+	migrateUp(t, db)
+
+	return db
+}
+
+func migrateUp(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	m, err := migrate.New(db)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("migrate.Up: %v", err)
+	}
+}
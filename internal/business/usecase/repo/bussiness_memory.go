@@ -0,0 +1,376 @@
+package repo
+
+import (
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// DefaultCategories are the categories every fresh store starts with. They
+// mirror the seed migration so both repositories accept the same aliases.
+var DefaultCategories = []entity.Categories{
+	{Alias: "fnb", Name: "fnb"},
+	{Alias: "office", Name: "office"},
+	{Alias: "factory", Name: "factory"},
+}
+
+// BusinessMemoryRepo is a concurrency-safe, in-memory BusinessRepo. It follows
+// the MySQL repository's semantics and is meant for tests and local development.
+type BusinessMemoryRepo struct {
+	mu         sync.RWMutex
+	businesses map[uint]entity.Business
+	categories map[string]entity.Categories
+	nextUUID   uint
+	l          logger.Interface
+}
+
+// NewBusinessMemoryRepo -.
+func NewBusinessMemoryRepo(l logger.Interface) *BusinessMemoryRepo {
+	mr := &BusinessMemoryRepo{
+		businesses: map[uint]entity.Business{},
+		categories: map[string]entity.Categories{},
+		l:          l,
+	}
+
+	for i, c := range DefaultCategories {
+		c.ID = uint(i + 1)
+		mr.categories[c.Alias] = c
+	}
+
+	return mr
+}
+
+func (mr *BusinessMemoryRepo) Create(ctx context.Context, b entity.Business) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	for _, existing := range mr.businesses {
+		if existing.ID == b.ID {
+			return fmt.Errorf("%w: id %s", entity.ErrAlreadyExists, b.ID)
+		}
+		if existing.Alias == b.Alias {
+			return fmt.Errorf("%w: alias %s", entity.ErrAlreadyExists, b.Alias)
+		}
+	}
+
+	now := time.Now()
+
+	mr.nextUUID++
+	b.UUID = mr.nextUUID
+	b.Categories = mr.findCategories(b.Categories)
+	b.Distance = 0
+	b.IsOpen = false
+	b.CreatedAt = now
+	b.UpdatedAt = now
+	b.DeletedAt = gorm.DeletedAt{}
+
+	mr.businesses[b.UUID] = clone(b)
+
+	return nil
+}
+
+func (mr *BusinessMemoryRepo) ReadById(ctx context.Context, id string) (entity.Business, error) {
+	if err := contextError(ctx); err != nil {
+		return entity.Business{}, err
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	b, ok := mr.find(id)
+	if !ok {
+		return entity.Business{}, entity.ErrNotFound
+	}
+
+	return view(ctx, b), nil
+}
+
+// UpdateById writes the non-zero fields of b, like GORM's Updates with a struct,
+// and replaces the categories with the ones matching b's aliases.
+func (mr *BusinessMemoryRepo) UpdateById(ctx context.Context, id string, b entity.Business) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	current, ok := mr.find(id)
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	if b.Alias != "" && b.Alias != current.Alias {
+		for _, existing := range mr.businesses {
+			if existing.Alias == b.Alias {
+				return fmt.Errorf("%w: alias %s", entity.ErrAlreadyExists, b.Alias)
+			}
+		}
+	}
+
+	current.Categories = mr.findCategories(b.Categories)
+	mergeNonZero(&current, b)
+	current.UpdatedAt = time.Now()
+
+	mr.businesses[current.UUID] = clone(current)
+
+	return nil
+}
+
+// SetOwner -.
+func (mr *BusinessMemoryRepo) SetOwner(ctx context.Context, id string, ownerID string) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	b, ok := mr.find(id)
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	b.OwnerID = ownerID
+	b.UpdatedAt = time.Now()
+	mr.businesses[b.UUID] = b
+
+	return nil
+}
+
+func (mr *BusinessMemoryRepo) DeleteById(ctx context.Context, id string) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	b, ok := mr.find(id)
+	if !ok {
+		return entity.ErrNotFound
+	}
+
+	b.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	mr.businesses[b.UUID] = b
+
+	return nil
+}
+
+func (mr *BusinessMemoryRepo) Search(ctx context.Context, limit uint, offset uint, price uint, attributes []string, categories []string, openAt time.Time, near entity.GeoFilter) ([]entity.Business, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	mr.mu.RLock()
+	defer mr.mu.RUnlock()
+
+	uuids := make([]uint, 0, len(mr.businesses))
+	for uuid := range mr.businesses {
+		uuids = append(uuids, uuid)
+	}
+	sort.Slice(uuids, func(i, j int) bool { return uuids[i] < uuids[j] })
+
+	var openAtTime time.Duration
+	if !openAt.IsZero() {
+		h, m, s := openAt.Clock()
+		openAtTime = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+	}
+
+	var matched []entity.Business
+	for _, uuid := range uuids {
+		b := mr.businesses[uuid]
+		if b.DeletedAt.Valid {
+			continue
+		}
+
+		if price != 0 && uint(utf8.RuneCountInString(b.Price)) != price {
+			continue
+		}
+
+		if len(attributes) > 0 && !containsAll(b.Attributes.Data, attributes) {
+			continue
+		}
+
+		if len(categories) > 0 && !hasAnyCategory(b.Categories, categories) {
+			continue
+		}
+
+		if !openAt.IsZero() && !(time.Duration(b.OpenTime) < openAtTime && time.Duration(b.CloseTime) > openAtTime) {
+			continue
+		}
+
+		if near.Enabled() {
+			b.Distance = near.Center.DistanceTo(b.Coordinates)
+			if b.Distance > near.Radius {
+				continue
+			}
+		}
+
+		matched = append(matched, b)
+	}
+
+	businesses := []entity.Business{}
+	for i := int(offset); i < len(matched) && uint(len(businesses)) < limit; i++ {
+		businesses = append(businesses, view(ctx, matched[i]))
+	}
+
+	return businesses, nil
+}
+
+// find returns the live business with the given public id. Callers hold mu.
+func (mr *BusinessMemoryRepo) find(id string) (entity.Business, bool) {
+	for _, b := range mr.businesses {
+		if b.ID == id && !b.DeletedAt.Valid {
+			return b, true
+		}
+	}
+	return entity.Business{}, false
+}
+
+// findCategories resolves categories by alias. Unknown aliases are ignored.
+func (mr *BusinessMemoryRepo) findCategories(cats []entity.Categories) []entity.Categories {
+	var found []entity.Categories
+	seen := map[string]bool{}
+	for _, c := range cats {
+		if stored, ok := mr.categories[c.Alias]; ok && !seen[c.Alias] {
+			seen[c.Alias] = true
+			found = append(found, stored)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+
+	return found
+}
+
+// mergeNonZero copies the persisted, non-zero fields of src into dst.
+func mergeNonZero(dst *entity.Business, src entity.Business) {
+	setString := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	setFloat := func(dst *float64, src float64) {
+		if src != 0 {
+			*dst = src
+		}
+	}
+
+	setString(&dst.Alias, src.Alias)
+	setFloat(&dst.Coordinates.Latitude, src.Coordinates.Latitude)
+	setFloat(&dst.Coordinates.Longitude, src.Coordinates.Longitude)
+	setString(&dst.DisplayPhone, src.DisplayPhone)
+	setString(&dst.ImageURL, src.ImageURL)
+	if src.OpenTime != 0 {
+		dst.OpenTime = src.OpenTime
+	}
+	if src.CloseTime != 0 {
+		dst.CloseTime = src.CloseTime
+	}
+	setString(&dst.Location.Address1, src.Location.Address1)
+	setString(&dst.Location.Address2, src.Location.Address2)
+	setString(&dst.Location.Address3, src.Location.Address3)
+	setString(&dst.Location.City, src.Location.City)
+	setString(&dst.Location.Country, src.Location.Country)
+	if src.Location.DisplayAddress.Data != "" {
+		dst.Location.DisplayAddress = src.Location.DisplayAddress
+	}
+	setString(&dst.Location.State, src.Location.State)
+	setString(&dst.Location.ZipCode, src.Location.ZipCode)
+	setString(&dst.Name, src.Name)
+	setString(&dst.Phone, src.Phone)
+	setString(&dst.Price, src.Price)
+	if src.Rating != 0 {
+		dst.Rating = src.Rating
+	}
+	if src.ReviewCount != 0 {
+		dst.ReviewCount = src.ReviewCount
+	}
+	if src.Transactions.Data != nil {
+		dst.Transactions = src.Transactions
+	}
+	if src.Attributes.Data != nil {
+		dst.Attributes = src.Attributes
+	}
+	setString(&dst.URL, src.URL)
+	setString(&dst.OwnerID, src.OwnerID)
+}
+
+// clone deep-copies the slices of b so stored values never alias caller memory.
+func clone(b entity.Business) entity.Business {
+	b.Categories = append([]entity.Categories(nil), b.Categories...)
+	b.Transactions = datatypes.JSONType[[]string]{Data: cloneStrings(b.Transactions.Data)}
+	b.Attributes = datatypes.JSONType[[]string]{Data: cloneStrings(b.Attributes.Data)}
+	return b
+}
+
+// view returns a copy of b for a reader, leaving out its categories when the
+// fieldset in ctx does, like the SQL repositories.
+func view(ctx context.Context, b entity.Business) entity.Business {
+	b = clone(b)
+	if !entity.FieldsetFrom(ctx).Has("categories") {
+		b.Categories = nil
+	}
+	return b
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
+
+func containsAll(have []string, want []string) bool {
+	set := make(map[string]bool, len(have))
+	for _, h := range have {
+		set[h] = true
+	}
+	for _, w := range want {
+		if !set[w] {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyCategory(cats []entity.Categories, aliases []string) bool {
+	for _, c := range cats {
+		for _, a := range aliases {
+			if c.Alias == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (mr *BusinessMemoryRepo) snapshot() func() {
+	mr.mu.RLock()
+	businesses := make(map[uint]entity.Business, len(mr.businesses))
+	for uuid, b := range mr.businesses {
+		businesses[uuid] = clone(b)
+	}
+	nextUUID := mr.nextUUID
+	mr.mu.RUnlock()
+
+	return func() {
+		mr.mu.Lock()
+		mr.businesses = businesses
+		mr.nextUUID = nextUUID
+		mr.mu.Unlock()
+	}
+}
@@ -0,0 +1,41 @@
+package repo_test
+
+import (
+	"testing"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	"backend-test/internal/business/usecase/repo/repotest"
+	"backend-test/pkg/logger"
+)
+
+func TestBusinessMemoryRepo(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) usecase.BusinessRepo {
+		return repo.NewBusinessMemoryRepo(logger.New("error"))
+	})
+}
+
+func TestUnitOfWorkMemory(t *testing.T) {
+	repotest.RunUnitOfWork(t, func(t *testing.T) repotest.Store {
+		business := repo.NewBusinessMemoryRepo(logger.New("error"))
+		audit := repo.NewAuditMemoryRepo()
+
+		return repotest.Store{
+			Business: business,
+			Audit:    audit,
+			UoW:      repo.NewMemoryUnitOfWork(business, audit),
+		}
+	})
+}
+
+func TestAPIKeyMemoryRepo(t *testing.T) {
+	repotest.RunAPIKeys(t, func(t *testing.T) usecase.APIKeyRepo {
+		return repo.NewAPIKeyMemoryRepo()
+	})
+}
+
+func TestClaimMemoryRepo(t *testing.T) {
+	repotest.RunClaims(t, func(t *testing.T) usecase.ClaimRepo {
+		return repo.NewClaimMemoryRepo()
+	})
+}
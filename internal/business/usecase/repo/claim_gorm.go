@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend-test/internal/db/instrument"
+	"backend-test/internal/entity"
+)
+
+// ClaimRepo -.
+type ClaimRepo struct {
+	db *gorm.DB
+}
+
+// NewClaimRepo -.
+func NewClaimRepo(db *gorm.DB) *ClaimRepo {
+	return &ClaimRepo{db: db}
+}
+
+// Create -.
+func (cr *ClaimRepo) Create(ctx context.Context, c entity.BusinessClaim) (entity.BusinessClaim, error) {
+	ctx = instrument.WithOperation(ctx, "claim_create")
+
+	if err := conn(ctx, cr.db).Create(&c).Error; err != nil {
+		return c, translateError(ctx, err)
+	}
+	return c, nil
+}
+
+// Get -.
+func (cr *ClaimRepo) Get(ctx context.Context, id uint) (entity.BusinessClaim, error) {
+	ctx = instrument.WithOperation(ctx, "claim_read")
+
+	var c entity.BusinessClaim
+	if err := conn(ctx, cr.db).Where("id = ?", id).First(&c).Error; err != nil {
+		return c, translateError(ctx, err)
+	}
+	return c, nil
+}
+
+// List filters on businessID and status when they are not empty, oldest first.
+func (cr *ClaimRepo) List(ctx context.Context, businessID string, status string) ([]entity.BusinessClaim, error) {
+	ctx = instrument.WithOperation(ctx, "claim_list")
+
+	q := conn(ctx, cr.db)
+	if businessID != "" {
+		q = q.Where("business_id = ?", businessID)
+	}
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+
+	claims := []entity.BusinessClaim{}
+	if err := q.Order("id").Find(&claims).Error; err != nil {
+		return nil, translateError(ctx, err)
+	}
+	return claims, nil
+}
+
+// Review updates the claim only while it is pending, so of two concurrent
+// reviews one finds no row to update.
+func (cr *ClaimRepo) Review(ctx context.Context, id uint, status string, reviewer string, at time.Time) error {
+	ctx = instrument.WithOperation(ctx, "claim_review")
+
+	result := conn(ctx, cr.db).Model(&entity.BusinessClaim{}).
+		Where("id = ? AND status = ?", id, entity.ClaimPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewer,
+			"reviewed_at": at,
+		})
+	if result.Error != nil {
+		return translateError(ctx, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if _, err := cr.Get(ctx, id); err != nil {
+			return err
+		}
+		return entity.ErrClaimReviewed
+	}
+	return nil
+}
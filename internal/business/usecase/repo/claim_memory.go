@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"backend-test/internal/entity"
+)
+
+// ClaimMemoryRepo -.
+type ClaimMemoryRepo struct {
+	mu     sync.RWMutex
+	claims []entity.BusinessClaim
+}
+
+// NewClaimMemoryRepo -.
+func NewClaimMemoryRepo() *ClaimMemoryRepo {
+	return &ClaimMemoryRepo{}
+}
+
+// Create -.
+func (cr *ClaimMemoryRepo) Create(ctx context.Context, c entity.BusinessClaim) (entity.BusinessClaim, error) {
+	if err := contextError(ctx); err != nil {
+		return c, err
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	c.ID = uint(len(cr.claims) + 1)
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	cr.claims = append(cr.claims, c)
+
+	return c, nil
+}
+
+// Get -.
+func (cr *ClaimMemoryRepo) Get(ctx context.Context, id uint) (entity.BusinessClaim, error) {
+	if err := contextError(ctx); err != nil {
+		return entity.BusinessClaim{}, err
+	}
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	for _, c := range cr.claims {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+
+	return entity.BusinessClaim{}, entity.ErrNotFound
+}
+
+// List filters on businessID and status when they are not empty, oldest first.
+func (cr *ClaimMemoryRepo) List(ctx context.Context, businessID string, status string) ([]entity.BusinessClaim, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+
+	claims := []entity.BusinessClaim{}
+	for _, c := range cr.claims {
+		if businessID != "" && c.BusinessID != businessID {
+			continue
+		}
+		if status != "" && c.Status != status {
+			continue
+		}
+		claims = append(claims, c)
+	}
+
+	return claims, nil
+}
+
+// Review updates the claim only while it is pending.
+func (cr *ClaimMemoryRepo) Review(ctx context.Context, id uint, status string, reviewer string, at time.Time) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	for i := range cr.claims {
+		if cr.claims[i].ID == id {
+			if cr.claims[i].Status != entity.ClaimPending {
+				return entity.ErrClaimReviewed
+			}
+			cr.claims[i].Status = status
+			cr.claims[i].ReviewedBy = reviewer
+			cr.claims[i].ReviewedAt = &at
+			return nil
+		}
+	}
+
+	return entity.ErrNotFound
+}
+
+func (cr *ClaimMemoryRepo) snapshot() func() {
+	cr.mu.RLock()
+	saved := append([]entity.BusinessClaim(nil), cr.claims...)
+	cr.mu.RUnlock()
+
+	return func() {
+		cr.mu.Lock()
+		cr.claims = saved
+		cr.mu.Unlock()
+	}
+}
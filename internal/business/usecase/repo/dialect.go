@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend-test/internal/entity"
+)
+
+// dialect builds the SQL fragments whose syntax differs between databases.
+type dialect string
+
+func dialectOf(db *gorm.DB) dialect {
+	return dialect(db.Dialector.Name())
+}
+
+// priceLength returns the expression for the number of characters in price.
+func (d dialect) priceLength() string {
+	if d == "sqlite" {
+		return "LENGTH(price)"
+	}
+	return "CHAR_LENGTH(price)"
+}
+
+// jsonArrayContains matches rows whose JSON array column holds every value.
+func (d dialect) jsonArrayContains(column string, values []string) clause.Expression {
+	switch d {
+	case "postgres":
+		data, _ := json.Marshal(values)
+		return clause.Expr{SQL: column + " @> ?::jsonb", Vars: []interface{}{string(data)}}
+	case "sqlite":
+		conds := make([]string, 0, len(values))
+		vars := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			conds = append(conds, "EXISTS (SELECT 1 FROM json_each("+column+") WHERE json_each.value = ?)")
+			vars = append(vars, v)
+		}
+		return clause.Expr{SQL: strings.Join(conds, " AND "), Vars: vars}
+	default:
+		return datatypes.JSONArrayQuery(column).Contains(values)
+	}
+}
+
+// withinRadius matches businesses whose coordinates lie within near.Radius meters
+// of near.Center. PostgreSQL uses PostGIS, SQLite the distance_meters function
+// registered by the sqlite package.
+func (d dialect) withinRadius(near entity.GeoFilter) clause.Expression {
+	lat, lon, radius := near.Center.Latitude, near.Center.Longitude, near.Radius
+
+	switch d {
+	case "postgres":
+		return clause.Expr{
+			SQL: "ST_DWithin(ST_SetSRID(ST_MakePoint(cord_longitude, cord_latitude), 4326)::geography, " +
+				"ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography, ?)",
+			Vars: []interface{}{lon, lat, radius},
+		}
+	case "sqlite":
+		return clause.Expr{
+			SQL:  "distance_meters(cord_latitude, cord_longitude, ?, ?) <= ?",
+			Vars: []interface{}{lat, lon, radius},
+		}
+	default:
+		return clause.Expr{
+			SQL:  "ST_Distance_Sphere(POINT(cord_longitude, cord_latitude), POINT(?, ?)) <= ?",
+			Vars: []interface{}{lon, lat, radius},
+		}
+	}
+}
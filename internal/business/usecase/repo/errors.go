@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+
+	"backend-test/internal/entity"
+)
+
+const (
+	_mysqlErrDuplicateEntry     = 1062
+	_postgresErrUniqueViolation = "23505"
+)
+
+// translateError maps driver and context errors onto the entity errors shared
+// by all repositories. Once ctx is done, the driver error is usually a side
+// effect of the cancellation, so the context error wins.
+func translateError(ctx context.Context, err error) error {
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		return ctxErr
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.ErrNotFound
+	}
+
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == _mysqlErrDuplicateEntry {
+		return fmt.Errorf("%w: %s", entity.ErrAlreadyExists, mysqlErr.Message)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == _postgresErrUniqueViolation {
+		return fmt.Errorf("%w: %s", entity.ErrAlreadyExists, pgErr.Message)
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey) {
+		return fmt.Errorf("%w: %s", entity.ErrAlreadyExists, sqliteErr.Error())
+	}
+
+	return err
+}
+
+// contextError returns entity.ErrCanceled or entity.ErrTimeout once ctx is done.
+func contextError(ctx context.Context) error {
+	switch err := ctx.Err(); {
+	case errors.Is(err, context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", entity.ErrTimeout, err)
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("%w: %s", entity.ErrCanceled, err)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+// Timeouts bounds each repository operation. A zero value leaves the
+// operation limited only by the caller's context.
+type Timeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+	Search time.Duration
+}
+
+// Option -.
+type Option func(*BusinessRepo)
+
+// QueryTimeouts -.
+func QueryTimeouts(t Timeouts) Option {
+	return func(br *BusinessRepo) {
+		br.timeouts = t
+	}
+}
+
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
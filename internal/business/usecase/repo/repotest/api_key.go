@@ -0,0 +1,124 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/entity"
+)
+
+// APIKeyFactory returns an empty API key repository.
+type APIKeyFactory func(t *testing.T) usecase.APIKeyRepo
+
+// RunAPIKeys checks a usecase.APIKeyRepo implementation.
+func RunAPIKeys(t *testing.T, newRepo APIKeyFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r usecase.APIKeyRepo)
+	}{
+		{"CreateAndFind", testAPIKeyCreateAndFind},
+		{"CreateDuplicate", testAPIKeyCreateDuplicate},
+		{"FindMissing", testAPIKeyFindMissing},
+		{"Revoke", testAPIKeyRevoke},
+		{"RevokeMissing", testAPIKeyRevokeMissing},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func newAPIKey(name, hash string) entity.APIKey {
+	return entity.APIKey{
+		Name:   name,
+		Prefix: "bk_" + hash[:8],
+		Hash:   hash,
+		Scopes: entity.ScopeRead + " " + entity.ScopeWrite,
+	}
+}
+
+func testAPIKeyCreateAndFind(t *testing.T, r usecase.APIKeyRepo) {
+	ctx := context.Background()
+
+	first, err := r.Create(ctx, newAPIKey("first", "aaaaaaaaaaaa"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	second, err := r.Create(ctx, newAPIKey("second", "bbbbbbbbbbbb"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if first.ID == 0 || second.ID == first.ID {
+		t.Fatalf("ids = %d, %d, want distinct non-zero ids", first.ID, second.ID)
+	}
+
+	got, err := r.FindByHash(ctx, "bbbbbbbbbbbb")
+	if err != nil {
+		t.Fatalf("FindByHash: %v", err)
+	}
+	if got.ID != second.ID || got.Name != "second" || got.Scopes != "read write" || got.Revoked() {
+		t.Errorf("FindByHash = %+v, want the live key %q", got, "second")
+	}
+
+	keys, err := r.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != first.ID || keys[1].ID != second.ID {
+		t.Errorf("List = %+v, want both keys oldest first", keys)
+	}
+}
+
+func testAPIKeyCreateDuplicate(t *testing.T, r usecase.APIKeyRepo) {
+	ctx := context.Background()
+
+	if _, err := r.Create(ctx, newAPIKey("first", "aaaaaaaaaaaa")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := r.Create(ctx, newAPIKey("again", "aaaaaaaaaaaa")); !errors.Is(err, entity.ErrAlreadyExists) {
+		t.Errorf("Create with a taken hash = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func testAPIKeyFindMissing(t *testing.T, r usecase.APIKeyRepo) {
+	if _, err := r.FindByHash(context.Background(), "missing"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("FindByHash = %v, want ErrNotFound", err)
+	}
+}
+
+func testAPIKeyRevoke(t *testing.T, r usecase.APIKeyRepo) {
+	ctx := context.Background()
+
+	k, err := r.Create(ctx, newAPIKey("first", "aaaaaaaaaaaa"))
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := r.Revoke(ctx, k.ID, at); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := r.Revoke(ctx, k.ID, time.Now()); err != nil {
+		t.Fatalf("Revoke again: %v", err)
+	}
+
+	got, err := r.FindByHash(ctx, k.Hash)
+	if err != nil {
+		t.Fatalf("FindByHash: %v", err)
+	}
+	if !got.Revoked() || !got.RevokedAt.Equal(at) {
+		t.Errorf("RevokedAt = %v, want the first revocation time %v", got.RevokedAt, at)
+	}
+}
+
+func testAPIKeyRevokeMissing(t *testing.T, r usecase.APIKeyRepo) {
+	if err := r.Revoke(context.Background(), 42, time.Now()); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Revoke = %v, want ErrNotFound", err)
+	}
+}
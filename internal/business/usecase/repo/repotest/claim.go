@@ -0,0 +1,169 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/entity"
+)
+
+// ClaimFactory returns an empty claim repository.
+type ClaimFactory func(t *testing.T) usecase.ClaimRepo
+
+// RunClaims checks a usecase.ClaimRepo implementation.
+func RunClaims(t *testing.T, newRepo ClaimFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r usecase.ClaimRepo)
+	}{
+		{"CreateAndGet", testClaimCreateAndGet},
+		{"GetMissing", testClaimGetMissing},
+		{"List", testClaimList},
+		{"Review", testClaimReview},
+		{"ReviewMissing", testClaimReviewMissing},
+		{"ReviewTwice", testClaimReviewTwice},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func mustCreateClaim(t *testing.T, r usecase.ClaimRepo, businessID, claimant string) entity.BusinessClaim {
+	t.Helper()
+
+	c, err := r.Create(context.Background(), entity.BusinessClaim{
+		BusinessID: businessID,
+		Claimant:   claimant,
+		Message:    "I run this place",
+		Status:     entity.ClaimPending,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return c
+}
+
+func claimIDs(claims []entity.BusinessClaim) []uint {
+	out := make([]uint, 0, len(claims))
+	for _, c := range claims {
+		out = append(out, c.ID)
+	}
+	return out
+}
+
+func testClaimCreateAndGet(t *testing.T, r usecase.ClaimRepo) {
+	first := mustCreateClaim(t, r, "b1", "user:1")
+	second := mustCreateClaim(t, r, "b1", "user:2")
+	if first.ID == 0 || second.ID == first.ID {
+		t.Fatalf("ids = %d, %d, want distinct non-zero ids", first.ID, second.ID)
+	}
+
+	got, err := r.Get(context.Background(), second.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.BusinessID != "b1" || got.Claimant != "user:2" || got.Message != "I run this place" ||
+		got.Status != entity.ClaimPending || got.ReviewedAt != nil || got.CreatedAt.IsZero() {
+		t.Errorf("Get = %+v, want the pending claim by user:2", got)
+	}
+}
+
+func testClaimGetMissing(t *testing.T, r usecase.ClaimRepo) {
+	if _, err := r.Get(context.Background(), 42); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+}
+
+func testClaimList(t *testing.T, r usecase.ClaimRepo) {
+	ctx := context.Background()
+
+	a := mustCreateClaim(t, r, "b1", "user:1")
+	b := mustCreateClaim(t, r, "b2", "user:1")
+	c := mustCreateClaim(t, r, "b1", "user:2")
+	if err := r.Review(ctx, c.ID, entity.ClaimRejected, "user:9", time.Now()); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+
+	tests := []struct {
+		business, status string
+		want             []uint
+	}{
+		{"", "", []uint{a.ID, b.ID, c.ID}},
+		{"b1", "", []uint{a.ID, c.ID}},
+		{"", entity.ClaimPending, []uint{a.ID, b.ID}},
+		{"b1", entity.ClaimRejected, []uint{c.ID}},
+		{"b3", "", []uint{}},
+	}
+	for _, tt := range tests {
+		got, err := r.List(ctx, tt.business, tt.status)
+		if err != nil {
+			t.Fatalf("List(%q, %q): %v", tt.business, tt.status, err)
+		}
+		if ids := claimIDs(got); !equalIDs(ids, tt.want) {
+			t.Errorf("List(%q, %q) = %v, want %v", tt.business, tt.status, ids, tt.want)
+		}
+	}
+}
+
+func equalIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testClaimReview(t *testing.T, r usecase.ClaimRepo) {
+	ctx := context.Background()
+
+	c := mustCreateClaim(t, r, "b1", "user:1")
+	at := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := r.Review(ctx, c.ID, entity.ClaimApproved, "user:9", at); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+
+	got, err := r.Get(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != entity.ClaimApproved || got.ReviewedBy != "user:9" || got.ReviewedAt == nil || !got.ReviewedAt.Equal(at) {
+		t.Errorf("Get = %+v, want approved by user:9 at %v", got, at)
+	}
+}
+
+func testClaimReviewTwice(t *testing.T, r usecase.ClaimRepo) {
+	ctx := context.Background()
+
+	c := mustCreateClaim(t, r, "b1", "user:1")
+	if err := r.Review(ctx, c.ID, entity.ClaimApproved, "user:9", time.Now()); err != nil {
+		t.Fatalf("Review: %v", err)
+	}
+	if err := r.Review(ctx, c.ID, entity.ClaimRejected, "user:8", time.Now()); !errors.Is(err, entity.ErrClaimReviewed) {
+		t.Errorf("second Review = %v, want ErrClaimReviewed", err)
+	}
+
+	got, err := r.Get(ctx, c.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != entity.ClaimApproved || got.ReviewedBy != "user:9" {
+		t.Errorf("Get = %+v, want it still approved by user:9", got)
+	}
+}
+
+func testClaimReviewMissing(t *testing.T, r usecase.ClaimRepo) {
+	if err := r.Review(context.Background(), 42, entity.ClaimApproved, "user:9", time.Now()); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("Review = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,482 @@
+// Package repotest holds the conformance suite every usecase.BusinessRepo
+// implementation must pass, so that storage backends stay interchangeable.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/entity"
+)
+
+// Factory returns an empty repository that knows the repo.DefaultCategories.
+type Factory func(t *testing.T) usecase.BusinessRepo
+
+// Run executes the conformance suite against repositories built by newRepo.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r usecase.BusinessRepo)
+	}{
+		{"CreateAndRead", testCreateAndRead},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"ReadMissing", testReadMissing},
+		{"Update", testUpdate},
+		{"UpdateMissing", testUpdateMissing},
+		{"SetOwner", testSetOwner},
+		{"Delete", testDelete},
+		{"SearchFilters", testSearchFilters},
+		{"SearchPagination", testSearchPagination},
+		{"SearchNear", testSearchNear},
+		{"Fieldset", testFieldset},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"CanceledContext", testCanceledContext},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func newBusiness(n int, categories ...string) entity.Business {
+	cats := make([]entity.Categories, 0, len(categories))
+	for _, c := range categories {
+		cats = append(cats, entity.Categories{Alias: c})
+	}
+
+	return entity.Business{
+		ID:           fmt.Sprintf("business-%d", n),
+		Alias:        fmt.Sprintf("alias-%d", n),
+		Name:         fmt.Sprintf("Business %d", n),
+		Categories:   cats,
+		Coordinates:  entity.Cordinates{Latitude: -6.2, Longitude: 106.8},
+		OpenTime:     datatypes.NewTime(9, 0, 0, 0),
+		CloseTime:    datatypes.NewTime(17, 0, 0, 0),
+		Location:     entity.Location{City: "Jakarta", DisplayAddress: datatypes.JSONType[string]{Data: "Jakarta"}},
+		Price:        "$$",
+		Rating:       4,
+		ReviewCount:  10,
+		Attributes:   datatypes.JSONType[[]string]{Data: []string{}},
+		Transactions: datatypes.JSONType[[]string]{Data: []string{}},
+	}
+}
+
+func mustCreate(t *testing.T, r usecase.BusinessRepo, b entity.Business) {
+	t.Helper()
+	if err := r.Create(context.Background(), b); err != nil {
+		t.Fatalf("Create(%s): %v", b.ID, err)
+	}
+}
+
+func aliases(cats []entity.Categories) []string {
+	out := make([]string, 0, len(cats))
+	for _, c := range cats {
+		out = append(out, c.Alias)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func ids(businesses []entity.Business) []string {
+	out := make([]string, 0, len(businesses))
+	for _, b := range businesses {
+		out = append(out, b.ID)
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func testCreateAndRead(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1, "fnb", "office", "unknown")
+	b.Attributes = datatypes.JSONType[[]string]{Data: []string{"wifi"}}
+	mustCreate(t, r, b)
+
+	got, err := r.ReadById(context.Background(), b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+
+	if got.Alias != b.Alias || got.Name != b.Name || got.Price != b.Price || got.Rating != b.Rating {
+		t.Errorf("ReadById = %+v, want fields of %+v", got, b)
+	}
+	if got.OpenTime != b.OpenTime || got.CloseTime != b.CloseTime {
+		t.Errorf("hours = %s-%s, want %s-%s", got.OpenTime, got.CloseTime, b.OpenTime, b.CloseTime)
+	}
+	if got.Location.City != "Jakarta" || got.Location.DisplayAddress.Data != "Jakarta" {
+		t.Errorf("location = %+v", got.Location)
+	}
+	if !equal(got.Attributes.Data, []string{"wifi"}) {
+		t.Errorf("attributes = %v, want [wifi]", got.Attributes.Data)
+	}
+	if want := []string{"fnb", "office"}; !equal(aliases(got.Categories), want) {
+		t.Errorf("categories = %v, want %v (unknown aliases are ignored)", aliases(got.Categories), want)
+	}
+}
+
+func testCreateDuplicate(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1)
+	mustCreate(t, r, b)
+
+	dupID := newBusiness(2)
+	dupID.ID = b.ID
+	if err := r.Create(context.Background(), dupID); !errors.Is(err, entity.ErrAlreadyExists) {
+		t.Errorf("Create with duplicate id: err = %v, want ErrAlreadyExists", err)
+	}
+
+	dupAlias := newBusiness(3)
+	dupAlias.Alias = b.Alias
+	if err := r.Create(context.Background(), dupAlias); !errors.Is(err, entity.ErrAlreadyExists) {
+		t.Errorf("Create with duplicate alias: err = %v, want ErrAlreadyExists", err)
+	}
+}
+
+func testReadMissing(t *testing.T, r usecase.BusinessRepo) {
+	if _, err := r.ReadById(context.Background(), "missing"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("ReadById(missing): err = %v, want ErrNotFound", err)
+	}
+}
+
+func testUpdate(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1, "fnb")
+	mustCreate(t, r, b)
+
+	err := r.UpdateById(context.Background(), b.ID, entity.Business{
+		Name:       "Renamed",
+		Categories: []entity.Categories{{Alias: "office"}, {Alias: "factory"}},
+		Attributes: datatypes.JSONType[[]string]{Data: []string{"parking"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateById: %v", err)
+	}
+
+	got, err := r.ReadById(context.Background(), b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+
+	if got.Name != "Renamed" {
+		t.Errorf("name = %q, want Renamed", got.Name)
+	}
+	if got.Alias != b.Alias || got.Price != b.Price || got.Rating != b.Rating {
+		t.Errorf("zero fields in the update must keep stored values, got %+v", got)
+	}
+	if !equal(got.Attributes.Data, []string{"parking"}) {
+		t.Errorf("attributes = %v, want [parking]", got.Attributes.Data)
+	}
+	if want := []string{"factory", "office"}; !equal(aliases(got.Categories), want) {
+		t.Errorf("categories = %v, want %v", aliases(got.Categories), want)
+	}
+}
+
+func testUpdateMissing(t *testing.T, r usecase.BusinessRepo) {
+	err := r.UpdateById(context.Background(), "missing", entity.Business{Name: "x"})
+	if !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("UpdateById(missing): err = %v, want ErrNotFound", err)
+	}
+}
+
+func testSetOwner(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1, "fnb")
+	mustCreate(t, r, b)
+
+	if err := r.SetOwner(context.Background(), b.ID, "user:7"); err != nil {
+		t.Fatalf("SetOwner: %v", err)
+	}
+
+	got, err := r.ReadById(context.Background(), b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if got.OwnerID != "user:7" {
+		t.Errorf("owner = %q, want user:7", got.OwnerID)
+	}
+	if got.Name != b.Name || !equal(aliases(got.Categories), []string{"fnb"}) {
+		t.Errorf("SetOwner must leave the other fields alone, got %+v", got)
+	}
+
+	if err := r.SetOwner(context.Background(), "missing", "user:7"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("SetOwner(missing): err = %v, want ErrNotFound", err)
+	}
+}
+
+func testDelete(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1)
+	mustCreate(t, r, b)
+
+	if err := r.DeleteById(context.Background(), b.ID); err != nil {
+		t.Fatalf("DeleteById: %v", err)
+	}
+
+	if _, err := r.ReadById(context.Background(), b.ID); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("ReadById after delete: err = %v, want ErrNotFound", err)
+	}
+
+	if err := r.DeleteById(context.Background(), b.ID); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("DeleteById twice: err = %v, want ErrNotFound", err)
+	}
+
+	got, err := r.Search(context.Background(), 10, 0, 0, nil, nil, time.Time{}, entity.GeoFilter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Search after delete = %v, want none", ids(got))
+	}
+}
+
+func testSearchFilters(t *testing.T, r usecase.BusinessRepo) {
+	cheap := newBusiness(1, "fnb")
+	cheap.Price = "$"
+	cheap.Attributes = datatypes.JSONType[[]string]{Data: []string{"wifi", "parking"}}
+
+	office := newBusiness(2, "office")
+	office.Attributes = datatypes.JSONType[[]string]{Data: []string{"wifi"}}
+
+	night := newBusiness(3, "fnb", "factory")
+	night.OpenTime = datatypes.NewTime(18, 0, 0, 0)
+	night.CloseTime = datatypes.NewTime(23, 0, 0, 0)
+
+	for _, b := range []entity.Business{cheap, office, night} {
+		mustCreate(t, r, b)
+	}
+
+	morning := time.Date(2023, 1, 2, 10, 0, 0, 0, time.Local)
+	evening := time.Date(2023, 1, 2, 20, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		price      uint
+		attributes []string
+		categories []string
+		openAt     time.Time
+		want       []string
+	}{
+		{name: "all", want: []string{cheap.ID, office.ID, night.ID}},
+		{name: "price", price: 1, want: []string{cheap.ID}},
+		{name: "attributes contain all", attributes: []string{"wifi", "parking"}, want: []string{cheap.ID}},
+		{name: "attributes contain one", attributes: []string{"wifi"}, want: []string{cheap.ID, office.ID}},
+		{name: "categories match any", categories: []string{"office", "factory"}, want: []string{office.ID, night.ID}},
+		{name: "open in the morning", openAt: morning, want: []string{cheap.ID, office.ID}},
+		{name: "open in the evening", openAt: evening, want: []string{night.ID}},
+		{name: "combined", price: 2, categories: []string{"fnb"}, openAt: evening, want: []string{night.ID}},
+	}
+
+	for _, tt := range tests {
+		got, err := r.Search(context.Background(), 10, 0, tt.price, tt.attributes, tt.categories, tt.openAt, entity.GeoFilter{})
+		if err != nil {
+			t.Fatalf("%s: Search: %v", tt.name, err)
+		}
+
+		gotIDs := ids(got)
+		sort.Strings(gotIDs)
+		want := append([]string(nil), tt.want...)
+		sort.Strings(want)
+		if !equal(gotIDs, want) {
+			t.Errorf("%s: Search = %v, want %v", tt.name, gotIDs, want)
+		}
+	}
+
+	got, err := r.Search(context.Background(), 10, 0, 0, nil, []string{"factory"}, time.Time{}, entity.GeoFilter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != 1 || !equal(aliases(got[0].Categories), []string{"factory", "fnb"}) {
+		t.Errorf("Search must preload every category of a match, got %+v", got)
+	}
+}
+
+func testSearchPagination(t *testing.T, r usecase.BusinessRepo) {
+	for i := 1; i <= 5; i++ {
+		mustCreate(t, r, newBusiness(i))
+	}
+
+	seen := map[string]bool{}
+	for offset := uint(0); offset < 6; offset += 2 {
+		got, err := r.Search(context.Background(), 2, offset, 0, nil, nil, time.Time{}, entity.GeoFilter{})
+		if err != nil {
+			t.Fatalf("Search(offset=%d): %v", offset, err)
+		}
+
+		wantLen := 2
+		if offset == 4 {
+			wantLen = 1
+		}
+		if len(got) != wantLen {
+			t.Errorf("Search(limit=2, offset=%d) returned %d businesses, want %d", offset, len(got), wantLen)
+		}
+
+		for _, b := range got {
+			if seen[b.ID] {
+				t.Errorf("business %s returned on more than one page", b.ID)
+			}
+			seen[b.ID] = true
+		}
+	}
+
+	if len(seen) != 5 {
+		t.Errorf("pages covered %d businesses, want 5", len(seen))
+	}
+}
+
+func testSearchNear(t *testing.T, r usecase.BusinessRepo) {
+	jakarta := newBusiness(1)
+	jakarta.Coordinates = entity.Cordinates{Latitude: -6.2088, Longitude: 106.8456}
+
+	bogor := newBusiness(2)
+	bogor.Coordinates = entity.Cordinates{Latitude: -6.5971, Longitude: 106.8060}
+
+	bandung := newBusiness(3)
+	bandung.Coordinates = entity.Cordinates{Latitude: -6.9175, Longitude: 107.6191}
+
+	for _, b := range []entity.Business{jakarta, bogor, bandung} {
+		mustCreate(t, r, b)
+	}
+
+	// Jakarta-Bogor is about 43km and Jakarta-Bandung about 115km.
+	tests := []struct {
+		radius float64
+		want   []string
+	}{
+		{radius: 10000, want: []string{jakarta.ID}},
+		{radius: 60000, want: []string{jakarta.ID, bogor.ID}},
+		{radius: 200000, want: []string{jakarta.ID, bogor.ID, bandung.ID}},
+	}
+
+	for _, tt := range tests {
+		near := entity.GeoFilter{Center: jakarta.Coordinates, Radius: tt.radius}
+		got, err := r.Search(context.Background(), 10, 0, 0, nil, nil, time.Time{}, near)
+		if err != nil {
+			t.Fatalf("Search(radius=%.0f): %v", tt.radius, err)
+		}
+
+		if gotIDs := ids(got); !equal(gotIDs, tt.want) {
+			t.Errorf("Search(radius=%.0f) = %v, want %v", tt.radius, gotIDs, tt.want)
+		}
+
+		for _, b := range got {
+			if b.Distance > tt.radius {
+				t.Errorf("business %s has distance %.0f beyond radius %.0f", b.ID, b.Distance, tt.radius)
+			}
+		}
+	}
+}
+
+func testFieldset(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1, "fnb")
+	mustCreate(t, r, b)
+
+	ctx := entity.WithFieldset(context.Background(), entity.Fieldset{"id", "name"})
+	got, err := r.ReadById(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if got.Name != b.Name || len(got.Categories) != 0 {
+		t.Errorf("ReadById without categories = %q, %v, want %q and none", got.Name, aliases(got.Categories), b.Name)
+	}
+
+	found, err := r.Search(ctx, 10, 0, 0, nil, []string{"fnb"}, time.Time{}, entity.GeoFilter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(found) != 1 || len(found[0].Categories) != 0 {
+		t.Errorf("Search without categories = %+v, want one match filtered by category but without them", found)
+	}
+
+	ctx = entity.WithFieldset(context.Background(), entity.Fieldset{"id", "categories"})
+	if got, err = r.ReadById(ctx, b.ID); err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if !equal(aliases(got.Categories), []string{"fnb"}) {
+		t.Errorf("ReadById with categories = %v, want [fnb]", aliases(got.Categories))
+	}
+}
+
+func testConcurrentCreate(t *testing.T, r usecase.BusinessRepo) {
+	const n = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 1; i <= n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- r.Create(context.Background(), newBusiness(i, "fnb"))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent Create: %v", err)
+		}
+	}
+
+	got, err := r.Search(context.Background(), 100, 0, 0, nil, []string{"fnb"}, time.Time{}, entity.GeoFilter{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != n {
+		t.Errorf("Search after concurrent creates returned %d businesses, want %d", len(got), n)
+	}
+}
+
+func testCanceledContext(t *testing.T, r usecase.BusinessRepo) {
+	b := newBusiness(1)
+	mustCreate(t, r, b)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ops := map[string]func() error{
+		"Create": func() error { return r.Create(ctx, newBusiness(2)) },
+		"ReadById": func() error {
+			_, err := r.ReadById(ctx, b.ID)
+			return err
+		},
+		"UpdateById": func() error { return r.UpdateById(ctx, b.ID, entity.Business{Name: "x"}) },
+		"DeleteById": func() error { return r.DeleteById(ctx, b.ID) },
+		"Search": func() error {
+			_, err := r.Search(ctx, 10, 0, 0, nil, nil, time.Time{}, entity.GeoFilter{})
+			return err
+		},
+	}
+
+	for name, op := range ops {
+		if err := op(); !errors.Is(err, entity.ErrCanceled) {
+			t.Errorf("%s with canceled context: err = %v, want ErrCanceled", name, err)
+		}
+	}
+
+	if _, err := r.ReadById(context.Background(), b.ID); err != nil {
+		t.Errorf("business must be untouched by canceled operations: %v", err)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := r.ReadById(ctx, b.ID); !errors.Is(err, entity.ErrTimeout) {
+		t.Errorf("ReadById past deadline: err = %v, want ErrTimeout", err)
+	}
+}
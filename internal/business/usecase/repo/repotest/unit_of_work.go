@@ -0,0 +1,164 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/entity"
+)
+
+// AuditRepo is the audit repository view the unit-of-work suite needs.
+type AuditRepo interface {
+	usecase.AuditRepo
+	List(ctx context.Context, entityName, entityID string) ([]entity.AuditEntry, error)
+}
+
+// Store is one backend's repositories sharing a unit of work.
+type Store struct {
+	Business usecase.BusinessRepo
+	Audit    AuditRepo
+	UoW      usecase.UnitOfWork
+}
+
+// StoreFactory returns an empty store that knows the repo.DefaultCategories.
+type StoreFactory func(t *testing.T) Store
+
+var errAbort = errors.New("abort")
+
+// RunUnitOfWork checks that writes made through a usecase.UnitOfWork commit
+// and roll back together.
+func RunUnitOfWork(t *testing.T, newStore StoreFactory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s Store)
+	}{
+		{"Commit", testUoWCommit},
+		{"RollbackCreate", testUoWRollbackCreate},
+		{"RollbackUpdate", testUoWRollbackUpdate},
+		{"Nested", testUoWNested},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+func createAudited(ctx context.Context, s Store, b entity.Business) error {
+	if err := s.Business.Create(ctx, b); err != nil {
+		return err
+	}
+	return s.Audit.Record(ctx, entity.AuditEntry{Action: entity.AuditCreate, Entity: "business", EntityID: b.ID})
+}
+
+func auditCount(t *testing.T, s Store, id string) int {
+	t.Helper()
+
+	entries, err := s.Audit.List(context.Background(), "business", id)
+	if err != nil {
+		t.Fatalf("Audit.List: %v", err)
+	}
+	return len(entries)
+}
+
+func testUoWCommit(t *testing.T, s Store) {
+	b := newBusiness(1, "fnb")
+
+	err := s.UoW.Do(context.Background(), func(ctx context.Context) error {
+		return createAudited(ctx, s, b)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	got, err := s.Business.ReadById(context.Background(), b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if !equal(aliases(got.Categories), []string{"fnb"}) {
+		t.Errorf("categories = %v, want [fnb]", aliases(got.Categories))
+	}
+	if n := auditCount(t, s, b.ID); n != 1 {
+		t.Errorf("audit entries = %d, want 1", n)
+	}
+}
+
+func testUoWRollbackCreate(t *testing.T, s Store) {
+	b := newBusiness(1, "fnb")
+
+	err := s.UoW.Do(context.Background(), func(ctx context.Context) error {
+		if err := createAudited(ctx, s, b); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Do = %v, want %v", err, errAbort)
+	}
+
+	if _, err := s.Business.ReadById(context.Background(), b.ID); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("ReadById after rollback = %v, want ErrNotFound", err)
+	}
+	if n := auditCount(t, s, b.ID); n != 0 {
+		t.Errorf("audit entries after rollback = %d, want 0", n)
+	}
+
+	// The rolled back alias must be free again.
+	mustCreate(t, s.Business, b)
+}
+
+func testUoWRollbackUpdate(t *testing.T, s Store) {
+	b := newBusiness(1, "fnb")
+	mustCreate(t, s.Business, b)
+
+	err := s.UoW.Do(context.Background(), func(ctx context.Context) error {
+		update := entity.Business{Name: "Renamed", Categories: []entity.Categories{{Alias: "office"}}}
+		if err := s.Business.UpdateById(ctx, b.ID, update); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Do = %v, want %v", err, errAbort)
+	}
+
+	got, err := s.Business.ReadById(context.Background(), b.ID)
+	if err != nil {
+		t.Fatalf("ReadById: %v", err)
+	}
+	if got.Name != b.Name {
+		t.Errorf("name after rollback = %q, want %q", got.Name, b.Name)
+	}
+	if !equal(aliases(got.Categories), []string{"fnb"}) {
+		t.Errorf("categories after rollback = %v, want [fnb]", aliases(got.Categories))
+	}
+}
+
+func testUoWNested(t *testing.T, s Store) {
+	outer, inner := newBusiness(1), newBusiness(2)
+
+	err := s.UoW.Do(context.Background(), func(ctx context.Context) error {
+		if err := createAudited(ctx, s, outer); err != nil {
+			return err
+		}
+		if err := s.UoW.Do(ctx, func(ctx context.Context) error {
+			return createAudited(ctx, s, inner)
+		}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Do = %v, want %v", err, errAbort)
+	}
+
+	for _, b := range []entity.Business{outer, inner} {
+		if _, err := s.Business.ReadById(context.Background(), b.ID); !errors.Is(err, entity.ErrNotFound) {
+			t.Errorf("ReadById(%s) after rollback = %v, want ErrNotFound", b.ID, err)
+		}
+	}
+}
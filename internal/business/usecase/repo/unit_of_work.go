@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// conn returns the transaction carried by ctx, or db when there is none. Every
+// GORM repository goes through it so calls made inside UnitOfWork.Do join the
+// surrounding transaction.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// transaction runs fn in the transaction carried by ctx, or in a new one.
+func transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(tx.WithContext(ctx))
+	}
+	return db.WithContext(ctx).Transaction(fn)
+}
+
+// GormUnitOfWork runs a group of repository calls in one database transaction.
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewGormUnitOfWork -.
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do commits when fn returns nil and rolls back otherwise. Nested calls join
+// the outermost transaction.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+
+	return translateError(ctx, err)
+}
+
+// snapshotter is implemented by the in-memory repositories: snapshot copies the
+// current state and returns a function that restores it.
+type snapshotter interface {
+	snapshot() (restore func())
+}
+
+type memoryTxKey struct{}
+
+// MemoryUnitOfWork gives the in-memory repositories all-or-nothing writes by
+// snapshotting them before fn and restoring the snapshot if fn fails. Units run
+// one at a time; writes made outside a unit while one is rolling back are lost,
+// which is acceptable for tests and local development only.
+type MemoryUnitOfWork struct {
+	mu    sync.Mutex
+	repos []snapshotter
+}
+
+// NewMemoryUnitOfWork -.
+func NewMemoryUnitOfWork(repos ...snapshotter) *MemoryUnitOfWork {
+	return &MemoryUnitOfWork{repos: repos}
+}
+
+// Do -.
+func (u *MemoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) == u {
+		return fn(ctx)
+	}
+
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	restores := make([]func(), 0, len(u.repos))
+	for _, r := range u.repos {
+		restores = append(restores, r.snapshot())
+	}
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, u)); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/controller/http/openapi"
+	"backend-test/pkg/health"
+)
+
+type healthRoutes struct {
+	h *health.Health
+}
+
+// newHealthRoutes registers the probes on the engine itself: they describe the
+// process, not an API version.
+func newHealthRoutes(handler *gin.Engine, h *health.Health) {
+	r := &healthRoutes{h}
+
+	handler.GET("/livez", r.livez)
+	handler.GET("/readyz", r.readyz)
+}
+
+func (r *healthRoutes) livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+func (r *healthRoutes) readyz(c *gin.Context) {
+	report := r.h.Readiness(c)
+	if !report.Ready() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// documentHealthRoutes describes the routes registered by newHealthRoutes.
+func documentHealthRoutes(s *openapi.Spec) {
+	status := &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{"status": {Type: "string"}}}
+	tags := []string{"health"}
+
+	s.Add(http.MethodGet, "/livez", &openapi.Operation{
+		OperationID: "livez",
+		Summary:     "Liveness probe",
+		Tags:        tags,
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "The process is running.", Content: openapi.JSON(status)},
+		},
+	})
+
+	report := &openapi.Response{Description: "Readiness per component.", Content: openapi.JSON(s.Ref("ReadinessReport", health.Report{}))}
+	s.Add(http.MethodGet, "/readyz", &openapi.Operation{
+		OperationID: "readyz",
+		Summary:     "Readiness probe",
+		Tags:        tags,
+		Responses: map[string]*openapi.Response{
+			"200": report,
+			"503": report,
+		},
+	})
+}
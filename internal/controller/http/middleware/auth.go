@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/entity"
+	"backend-test/pkg/jwtauth"
+	"backend-test/pkg/logger"
+)
+
+// HeaderAPIKey carries the API key of machine clients.
+const HeaderAPIKey = "X-API-Key"
+
+// KeyAuthenticator resolves an API key into the caller it belongs to.
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (entity.Principal, error)
+}
+
+// TokenVerifier checks the bearer tokens of dashboard users.
+type TokenVerifier interface {
+	Verify(token string) (jwtauth.Claims, error)
+}
+
+// Authenticate stores the principal of the request's API key or bearer token
+// in the request context and adds it to the request logger as the user.
+// Requests without credentials pass through anonymously, so RequireScope
+// decides whether a route needs them; requests with invalid ones are rejected
+// with 401. tokens may be nil, in which case bearer tokens are rejected.
+func Authenticate(keys KeyAuthenticator, tokens TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			p   entity.Principal
+			err error
+		)
+
+		key := c.GetHeader(HeaderAPIKey)
+		token, isBearer := bearerToken(c.GetHeader("Authorization"))
+
+		switch {
+		case key != "":
+			p, err = keys.Authenticate(c, key)
+		case isBearer:
+			p, err = verifyToken(c, tokens, token)
+		default:
+			c.Next()
+			return
+		}
+
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, entity.ErrUnauthenticated) {
+				status = http.StatusUnauthorized
+			}
+			abortWithError(c, status, err)
+			return
+		}
+
+		setPrincipal(c, p)
+		c.Next()
+	}
+}
+
+// RequireScope rejects requests whose principal lacks scope: 401 when there
+// is no principal, 403 when its scopes do not include scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := entity.PrincipalFrom(c)
+		if !ok {
+			c.Header("WWW-Authenticate", "Bearer")
+			abortWithError(c, http.StatusUnauthorized, entity.ErrUnauthenticated)
+			return
+		}
+
+		if !p.HasScope(scope) {
+			abortWithError(c, http.StatusForbidden, entity.ErrForbidden)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func verifyToken(c *gin.Context, tokens TokenVerifier, token string) (entity.Principal, error) {
+	if tokens == nil {
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+
+	claims, err := tokens.Verify(token)
+	if err != nil {
+		// The reason stays in the debug log; clients only learn the token
+		// was rejected.
+		if l := logger.FromContext(c, nil); l != nil {
+			l.Debug("middleware - Authenticate - tokens.Verify: %s", err)
+		}
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+
+	return entity.NewUserPrincipal(claims.Subject, claims.Roles), nil
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer" header.
+func bearerToken(header string) (string, bool) {
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
+
+func setPrincipal(c *gin.Context, p entity.Principal) {
+	ctx := entity.WithPrincipal(c.Request.Context(), p)
+	if l := logger.FromContext(ctx, nil); l != nil {
+		ctx = logger.WithContext(ctx, l.With(logger.Fields{logger.FieldUser: p.Subject}))
+	}
+
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// abortWithError ends the request with the same error envelope as the API
+// handlers.
+func abortWithError(c *gin.Context, code int, err error) {
+	c.AbortWithStatusJSON(code, gin.H{
+		"status":     "ERROR",
+		"error":      err.Error(),
+		"request_id": GetRequestID(c),
+	})
+}
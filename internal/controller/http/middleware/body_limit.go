@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrBodyTooLarge is returned when a request body exceeds the limit of
+// BodyLimit.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// BodyLimit rejects requests whose body is larger than max bytes with 413,
+// before any handler binds it. Bodies of unknown length are read up to the
+// limit and buffered.
+func BodyLimit(max int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		tooLarge := func() {
+			abortWithError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("%w: the limit is %d bytes", ErrBodyTooLarge, max))
+		}
+
+		if c.Request.ContentLength > max {
+			tooLarge()
+			return
+		}
+
+		if c.Request.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, max+1))
+			if err != nil {
+				abortWithError(c, http.StatusBadRequest, fmt.Errorf("read body: %w", err))
+				return
+			}
+			if int64(len(body)) > max {
+				tooLarge()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			c.Request.ContentLength = int64(len(body))
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultExcludedTypes are the media types CompressOptions.ExcludedTypes
+// falls back to: formats that are compressed already and would only cost CPU.
+var DefaultExcludedTypes = []string{
+	"image/", "video/", "audio/", "font/woff", "font/woff2",
+	"application/zip", "application/gzip", "application/x-gzip", "application/zstd",
+	"application/x-bzip2", "application/x-xz", "application/x-7z-compressed", "application/vnd.rar",
+}
+
+// _brotliLevel trades ratio for speed: brotli's default of 6 is too slow to
+// run on every response.
+const _brotliLevel = 4
+
+// CompressOptions configure Compress.
+type CompressOptions struct {
+	// MinSize is the smallest body, in bytes, worth compressing.
+	MinSize int
+	// ExcludedTypes are media types, or prefixes ending in "/" such as
+	// "image/", sent as is; DefaultExcludedTypes when nil. SVG images are
+	// text and always compressible.
+	ExcludedTypes []string
+}
+
+// Compress encodes response bodies with brotli or gzip, whichever the client
+// prefers in Accept-Encoding, brotli on a tie.
+//
+// The body is held back until MinSize bytes are written, so smaller bodies go
+// out as is. Responses that already have a Content-Encoding, such as
+// /metrics, responses of an excluded type and partial or bodiless responses
+// are not touched. A handler that flushes before MinSize is streaming a body
+// of unknown length, which is then compressed.
+func Compress(o CompressOptions) gin.HandlerFunc {
+	if o.ExcludedTypes == nil {
+		o.ExcludedTypes = DefaultExcludedTypes
+	}
+
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, opts: &o}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding returns "br", "gzip" or "" for identity, following the
+// quality values of an Accept-Encoding header.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	q := map[string]float64{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		quality := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				continue
+			}
+			quality = f
+		}
+		q[name] = quality
+	}
+
+	quality := func(name string) float64 {
+		if v, ok := q[name]; ok {
+			return v
+		}
+		if name == "gzip" {
+			if v, ok := q["x-gzip"]; ok {
+				return v
+			}
+		}
+		return q["*"]
+	}
+
+	br, gz := quality("br"), quality("gzip")
+	switch {
+	case br > 0 && br >= gz:
+		return "br"
+	case gz > 0:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+var (
+	_gzipWriters = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(io.Discard)
+	}}
+	_brotliWriters = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, _brotliLevel)
+	}}
+)
+
+// encoder is the part of gzip.Writer and brotli.Writer compressWriter uses.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressWriter buffers the start of the body until it can tell whether to
+// compress it, then sends everything through an encoder or straight through.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	opts     *CompressOptions
+
+	buf     []byte
+	decided bool
+	enc     encoder
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.opts.MinSize {
+			return len(b), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+
+	if w.enc != nil {
+		return w.enc.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers of a bodiless response, such as one
+// aborted with a status only.
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Written reports whether the handler has written anything, even if it is
+// still held back.
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Hijack hands the connection over as is; nothing is compressed after it.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide picks between compressing and passing the body through, compressing
+// only when large is set and the response allows it, and writes out what was
+// held back.
+func (w *compressWriter) decide(large bool) error {
+	w.decided = true
+
+	if large && w.compressible() {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+
+		if w.encoding == "br" {
+			w.enc = _brotliWriters.Get().(encoder)
+		} else {
+			w.enc = _gzipWriters.Get().(encoder)
+		}
+		w.enc.Reset(w.ResponseWriter)
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.enc != nil {
+		_, err := w.enc.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+func (w *compressWriter) compressible() bool {
+	switch status := w.Status(); {
+	case status < http.StatusOK, status == http.StatusNoContent,
+		status == http.StatusPartialContent, status == http.StatusNotModified:
+		return false
+	}
+
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+
+	ct := h.Get("Content-Type")
+	if ct == "" {
+		ct = http.DetectContentType(w.buf)
+		h.Set("Content-Type", ct)
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	if mediaType == "image/svg+xml" {
+		return true
+	}
+	for _, excluded := range w.opts.ExcludedTypes {
+		if mediaType == excluded || strings.HasSuffix(excluded, "/") && strings.HasPrefix(mediaType, excluded) {
+			return false
+		}
+	}
+	return true
+}
+
+// close sends what is still held back and finishes the encoded stream.
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide(len(w.buf) >= w.opts.MinSize && len(w.buf) > 0)
+	}
+	if w.enc == nil {
+		return
+	}
+
+	_ = w.enc.Close()
+	w.enc.Reset(io.Discard)
+	if w.encoding == "br" {
+		_brotliWriters.Put(w.enc)
+	} else {
+		_gzipWriters.Put(w.enc)
+	}
+	w.enc = nil
+}
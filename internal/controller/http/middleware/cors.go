@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultExposedHeaders are the response headers of this package that
+// scripts may read when CORSOptions.ExposedHeaders is nil.
+var DefaultExposedHeaders = []string{
+	HeaderRequestID,
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+	"Deprecation", "Link",
+}
+
+// CORSOptions configure CORS.
+type CORSOptions struct {
+	// AllowedOrigins are exact origins such as "https://app.example.com",
+	// origins with a wildcard subdomain such as "https://*.example.com", or
+	// "*" for any origin.
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowedHeaders are the request headers browsers may send; "*" allows
+	// whatever a preflight asks for.
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers scripts may read;
+	// DefaultExposedHeaders when nil.
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies and Authorization headers.
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// CORS answers preflight requests and adds the Access-Control-* headers to
+// the responses of allowed origins. Requests from other origins get no CORS
+// headers, so browsers hide the response; their preflights, and those asking
+// for a method not allowed, get 403.
+func CORS(o CORSOptions) gin.HandlerFunc {
+	methods := strings.Join(o.AllowedMethods, ", ")
+	headers := strings.Join(o.AllowedHeaders, ", ")
+	if o.ExposedHeaders == nil {
+		o.ExposedHeaders = DefaultExposedHeaders
+	}
+	exposed := strings.Join(o.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(o.MaxAge.Seconds()))
+	anyHeader := contains(o.AllowedHeaders, "*")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		allowed := originAllowed(o.AllowedOrigins, origin) &&
+			(!preflight || contains(o.AllowedMethods, c.GetHeader("Access-Control-Request-Method")))
+		if !allowed {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		h.Set("Access-Control-Allow-Origin", origin)
+		if o.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if exposed != "" {
+				h.Set("Access-Control-Expose-Headers", exposed)
+			}
+			c.Next()
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", methods)
+		if anyHeader {
+			h.Set("Access-Control-Allow-Headers", c.GetHeader("Access-Control-Request-Headers"))
+		} else if headers != "" {
+			h.Set("Access-Control-Allow-Headers", headers)
+		}
+		if o.MaxAge > 0 {
+			h.Set("Access-Control-Max-Age", maxAge)
+		}
+
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || strings.EqualFold(a, origin) {
+			return true
+		}
+
+		// "https://*.example.com" matches any subdomain, not the apex.
+		if scheme, domain, ok := strings.Cut(strings.ToLower(a), "*."); ok {
+			host, found := strings.CutPrefix(strings.ToLower(origin), scheme)
+			if found && len(host) > len(domain)+1 && strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+// Package middleware holds gin middleware shared by every API version.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated marks responses of legacy routes as deprecated and points
+// clients at the same path under successorPrefix.
+func Deprecated(successorPrefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", "<"+successorPrefix+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"backend-test/pkg/logger"
+)
+
+// Logger stores a child of l carrying the request's route and ID in the
+// request context, where logger.FromContext finds it. Install it after
+// RequestID.
+func Logger(l logger.Interface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = _unmatchedRoute
+		}
+
+		fields := logger.Fields{logger.FieldRoute: route}
+		if id := GetRequestID(c.Request.Context()); id != "" {
+			fields[logger.FieldRequestID] = id
+		}
+
+		ctx := logger.AddFields(c.Request.Context(), l, fields)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
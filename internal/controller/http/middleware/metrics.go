@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// _unmatchedRoute labels requests no route matched, so arbitrary paths do not
+// create new series.
+const _unmatchedRoute = "unmatched"
+
+var (
+	_requests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	_requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route template, method and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	_inFlight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "HTTP requests being served by route template and method.",
+	}, []string{"route", "method"})
+)
+
+// Metrics records every request under its route template, e.g.
+// /v1/business/:id, rather than the raw path.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = _unmatchedRoute
+		}
+		method := c.Request.Method
+
+		inFlight := _inFlight.WithLabelValues(route, method)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		c.Next()
+
+		status := strconv.Itoa(c.Writer.Status())
+		_requests.WithLabelValues(route, method, status).Inc()
+		_requestDuration.WithLabelValues(route, method, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// requestSeries returns the request count and the number of latency
+// observations recorded on reg for labels, or false if there is no series.
+func requestSeries(t *testing.T, reg *prometheus.Registry, labels map[string]string) (float64, uint64, bool) {
+	t.Helper()
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	var (
+		count        float64
+		observations uint64
+		found        bool
+	)
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			matched := 0
+			for _, label := range m.GetLabel() {
+				if want, ok := labels[label.GetName()]; ok && want == label.GetValue() {
+					matched++
+				}
+			}
+			if matched != len(labels) {
+				continue
+			}
+			switch f.GetName() {
+			case "http_requests_total":
+				count, found = m.GetCounter().GetValue(), true
+			case "http_request_duration_seconds":
+				observations = m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return count, observations, found
+}
+
+func TestMetrics(t *testing.T) {
+	// The collectors are package globals on the default registry; a private
+	// one shows only them.
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(_requests, _requestDuration)
+
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	handler.Use(Metrics())
+	handler.GET("/v1/business/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/v1/business/abc", "/v1/business/def", "/no/such/route"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	count, observations, ok := requestSeries(t, reg, map[string]string{"route": "/v1/business/:id", "method": "GET", "status": "200"})
+	if !ok || count < 2 || observations < 2 {
+		t.Errorf("route template series = %v requests, %d observations, want both requests", count, observations)
+	}
+	if _, _, ok := requestSeries(t, reg, map[string]string{"route": "/v1/business/abc"}); ok {
+		t.Errorf("found a series labelled with the raw path")
+	}
+
+	count, observations, ok = requestSeries(t, reg, map[string]string{"route": _unmatchedRoute, "status": "404"})
+	if !ok || count < 1 || observations < 1 {
+		t.Errorf("unmatched series = %v requests, %d observations, want the 404", count, observations)
+	}
+	if _, _, ok := requestSeries(t, reg, map[string]string{"route": "/no/such/route"}); ok {
+		t.Errorf("found a series labelled with an unmatched path")
+	}
+}
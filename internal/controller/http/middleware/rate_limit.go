@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+	"backend-test/pkg/ratelimit"
+)
+
+// RateLimit takes a token from the bucket of the caller and route, answering
+// 429 with Retry-After once it is empty. Callers are told apart by their
+// principal, so it must run after Authenticate, or by client IP when
+// anonymous.
+//
+// Routes are looked up in routes as "METHOD /path", with path the route
+// template below prefix, such as "GET /business/search"; the others get def.
+// Every API version mounted under its own prefix thus shares the limits and
+// the buckets. The RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers describe the bucket. When the store fails the
+// request goes through.
+func RateLimit(store ratelimit.Store, def ratelimit.Limit, routes map[string]ratelimit.Limit, prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if path == "" {
+			c.Next()
+			return
+		}
+
+		route := c.Request.Method + " " + strings.TrimPrefix(path, prefix)
+		limit, ok := routes[route]
+		if !ok {
+			limit = def
+		}
+		if !limit.Enabled() {
+			c.Next()
+			return
+		}
+
+		res, err := store.Take(c, clientKey(c)+" "+route, limit)
+		if err != nil {
+			if l := logger.FromContext(c, nil); l != nil {
+				l.Error(fmt.Errorf("middleware - RateLimit - store.Take: %w", err))
+			}
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Requests, ceilSeconds(limit.Per)))
+
+		if !res.Allowed {
+			h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+			abortWithError(c, http.StatusTooManyRequests, entity.ErrRateLimited)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller: its API key or user, or its IP address.
+func clientKey(c *gin.Context) string {
+	if p, ok := entity.PrincipalFrom(c); ok {
+		return p.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds formats d as whole seconds, rounded up so clients never retry
+// too early.
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderRequestID carries the request ID in requests and responses.
+const HeaderRequestID = "X-Request-ID"
+
+// _maxRequestIDLength bounds client-supplied IDs, which end up in every log
+// line of the request.
+const _maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID identifies each request by the client's X-Request-ID header, or a
+// generated ID if it is missing or malformed. The ID is echoed in the
+// response header, stored in the request context and added to the span
+// started by Tracing, so it must be installed after it.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Header(HeaderRequestID, id)
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, id)
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("http.request_id", id))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID set by RequestID, or "".
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID accepts short IDs of printable ASCII without spaces, so a
+// client cannot inject anything into logs or headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > _maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,372 @@
+// Package openapi builds the OpenAPI 3 document of the HTTP API from Go types.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/datatypes"
+)
+
+// OpenAPI 3 document types, limited to what this API uses.
+type (
+	document struct {
+		OpenAPI    string               `json:"openapi"`
+		Info       Info                 `json:"info"`
+		Servers    []server             `json:"servers"`
+		Paths      map[string]*pathItem `json:"paths"`
+		Components components           `json:"components"`
+	}
+
+	// Info -.
+	Info struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		Version     string `json:"version"`
+	}
+
+	server struct {
+		URL string `json:"url"`
+	}
+
+	components struct {
+		Schemas         map[string]*Schema         `json:"schemas"`
+		SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+	}
+
+	// SecurityScheme -.
+	SecurityScheme struct {
+		Type         string `json:"type"`
+		In           string `json:"in,omitempty"`
+		Name         string `json:"name,omitempty"`
+		Scheme       string `json:"scheme,omitempty"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+		Description  string `json:"description,omitempty"`
+	}
+
+	pathItem struct {
+		Get    *Operation `json:"get,omitempty"`
+		Post   *Operation `json:"post,omitempty"`
+		Put    *Operation `json:"put,omitempty"`
+		Delete *Operation `json:"delete,omitempty"`
+	}
+
+	// Operation -.
+	Operation struct {
+		OperationID string               `json:"operationId"`
+		Summary     string               `json:"summary"`
+		Tags        []string             `json:"tags"`
+		Deprecated  bool                 `json:"deprecated,omitempty"`
+		Parameters  []Parameter          `json:"parameters,omitempty"`
+		RequestBody *RequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*Response `json:"responses"`
+
+		// Security lists the schemes that authorize the operation, each
+		// with the scopes it needs.
+		Security []map[string][]string `json:"security,omitempty"`
+	}
+
+	// Parameter -.
+	Parameter struct {
+		Name        string  `json:"name"`
+		In          string  `json:"in"`
+		Description string  `json:"description,omitempty"`
+		Required    bool    `json:"required,omitempty"`
+		Schema      *Schema `json:"schema"`
+	}
+
+	// RequestBody -.
+	RequestBody struct {
+		Required bool                  `json:"required"`
+		Content  map[string]*MediaType `json:"content"`
+	}
+
+	// Response -.
+	Response struct {
+		Description string                `json:"description"`
+		Content     map[string]*MediaType `json:"content,omitempty"`
+	}
+
+	// MediaType -.
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	// Schema -.
+	Schema struct {
+		Ref         string             `json:"$ref,omitempty"`
+		Type        string             `json:"type,omitempty"`
+		Format      string             `json:"format,omitempty"`
+		Description string             `json:"description,omitempty"`
+		Example     interface{}        `json:"example,omitempty"`
+		Enum        []string           `json:"enum,omitempty"`
+		MinLength   *int               `json:"minLength,omitempty"`
+		MaxLength   *int               `json:"maxLength,omitempty"`
+		Minimum     *float64           `json:"minimum,omitempty"`
+		Maximum     *float64           `json:"maximum,omitempty"`
+		Items       *Schema            `json:"items,omitempty"`
+		Properties  map[string]*Schema `json:"properties,omitempty"`
+		Required    []string           `json:"required,omitempty"`
+
+		AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	}
+)
+
+// Spec is an OpenAPI document under construction. It is not safe for
+// concurrent use until it is served.
+type Spec struct {
+	doc   *document
+	names map[reflect.Type]string
+}
+
+// New -.
+func New(info Info) *Spec {
+	return &Spec{
+		doc: &document{
+			OpenAPI:    "3.0.3",
+			Info:       info,
+			Servers:    []server{{URL: "/"}},
+			Paths:      map[string]*pathItem{},
+			Components: components{Schemas: map[string]*Schema{}},
+		},
+		names: map[reflect.Type]string{},
+	}
+}
+
+// Add documents method on a gin route path such as /v1/business/:id.
+func (s *Spec) Add(method, path string, op *Operation) {
+	path = ginToOpenAPIPath(path)
+
+	item, ok := s.doc.Paths[path]
+	if !ok {
+		item = &pathItem{}
+		s.doc.Paths[path] = item
+	}
+
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodDelete:
+		item.Delete = op
+	}
+}
+
+// Handler serves the document as JSON.
+func (s *Spec) Handler(c *gin.Context) {
+	c.JSON(http.StatusOK, s.doc)
+}
+
+func ginToOpenAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") || strings.HasPrefix(p, "*") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// SecurityScheme registers a way of authenticating under name.
+func (s *Spec) SecurityScheme(name string, scheme *SecurityScheme) {
+	if s.doc.Components.SecuritySchemes == nil {
+		s.doc.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+	s.doc.Components.SecuritySchemes[name] = scheme
+}
+
+// Name makes every occurrence of v's type a reference to the component name,
+// instead of an inline schema.
+func (s *Spec) Name(name string, v interface{}) {
+	s.names[reflect.TypeOf(v)] = name
+}
+
+// Ref registers the schema of v under name and returns a reference to it.
+func (s *Spec) Ref(name string, v interface{}) *Schema {
+	if _, ok := s.doc.Components.Schemas[name]; !ok {
+		s.doc.Components.Schemas[name] = nil // guards against recursion
+		s.doc.Components.Schemas[name] = s.schemaOf(reflect.TypeOf(v))
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+var (
+	_timeType   = reflect.TypeOf(time.Time{})
+	_clockType  = reflect.TypeOf(datatypes.Time(0))
+	_jsonTypePk = reflect.TypeOf(datatypes.JSONType[string]{}).PkgPath()
+)
+
+// schemaOf derives a schema from a Go type the way encoding/json serializes
+// it, honouring binding/validate tags for required fields and bounds.
+func (s *Spec) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if name, ok := s.names[t]; ok {
+		return s.Ref(name, reflect.Zero(t).Interface())
+	}
+
+	switch {
+	case t == _timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == _clockType:
+		return &Schema{Type: "string", Format: "time", Example: "09:00:00"}
+	case t.PkgPath() == _jsonTypePk && strings.HasPrefix(t.Name(), "JSONType["):
+		f, _ := t.FieldByName("Data")
+		return s.schemaOf(f.Type)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return s.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (s *Spec) structSchema(t reflect.Type) *Schema {
+	out := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		// Like encoding/json, promote the fields of untagged embedded structs.
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			embedded := s.structSchema(f.Type)
+			for k, v := range embedded.Properties {
+				out.Properties[k] = v
+			}
+			out.Required = append(out.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := s.schemaOf(f.Type)
+		rules := strings.Split(f.Tag.Get("binding")+","+f.Tag.Get("validate"), ",")
+		for _, rule := range rules {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case "required":
+				out.Required = append(out.Required, name)
+			case "gte", "lte":
+				applyBound(prop, key, value)
+			}
+		}
+
+		out.Properties[name] = prop
+	}
+
+	return out
+}
+
+// applyBound maps a validator gte/lte rule onto a length or a numeric bound.
+func applyBound(prop *Schema, rule, value string) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || prop.Ref != "" {
+		return
+	}
+
+	if prop.Type == "string" {
+		l := int(n)
+		if rule == "gte" {
+			if l > 0 {
+				prop.MinLength = &l
+			}
+		} else {
+			prop.MaxLength = &l
+		}
+		return
+	}
+
+	if rule == "gte" {
+		prop.Minimum = &n
+	} else {
+		prop.Maximum = &n
+	}
+}
+
+// QueryParameters derives query parameters from the form tags of v.
+func (s *Spec) QueryParameters(v interface{}, descriptions map[string]string) []Parameter {
+	t := reflect.TypeOf(v)
+
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		params = append(params, Parameter{
+			Name:        name,
+			In:          "query",
+			Description: descriptions[name],
+			Schema:      s.schemaOf(f.Type),
+		})
+	}
+
+	return params
+}
+
+// JSON is the content of a JSON request or response body.
+func JSON(sc *Schema) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: sc}}
+}
+
+// _swaggerUI loads Swagger UI from a CDN and points it at /openapi.json.
+const _swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>API documentation</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.9.0/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => { window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" }); };
+  </script>
+</body>
+</html>
+`
+
+// SwaggerUI serves an interactive explorer for the document at /openapi.json.
+func SwaggerUI(c *gin.Context) {
+	// The page needs the CDN and its inline bootstrap script, which the
+	// API's default policy forbids.
+	c.Header("Content-Security-Policy", "default-src 'none'; script-src https://unpkg.com 'unsafe-inline'; "+
+		"style-src https://unpkg.com; img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(_swaggerUI))
+}
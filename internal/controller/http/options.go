@@ -0,0 +1,97 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/pkg/ratelimit"
+)
+
+// Option -.
+type Option func(*routerOptions)
+
+type routerOptions struct {
+	swaggerUI    bool
+	legacyRoutes bool
+	publicRead   bool
+	tokens       middleware.TokenVerifier
+	rateLimit    *rateLimit
+	cors         *middleware.CORSOptions
+	compress     *middleware.CompressOptions
+	maxBodyBytes int64
+}
+
+type rateLimit struct {
+	store  ratelimit.Store
+	def    ratelimit.Limit
+	routes map[string]ratelimit.Limit
+}
+
+// SwaggerUI serves an interactive API explorer at /swagger.
+func SwaggerUI(enabled bool) Option {
+	return func(o *routerOptions) {
+		o.swaggerUI = enabled
+	}
+}
+
+// LegacyRoutes keeps serving the v1 API without its /v1 prefix, flagged as
+// deprecated.
+func LegacyRoutes(enabled bool) Option {
+	return func(o *routerOptions) {
+		o.legacyRoutes = enabled
+	}
+}
+
+// PublicRead lets reads and searches through without an API key.
+func PublicRead(enabled bool) Option {
+	return func(o *routerOptions) {
+		o.publicRead = enabled
+	}
+}
+
+// Tokens accepts bearer tokens checked by v, next to API keys.
+func Tokens(v middleware.TokenVerifier) Option {
+	return func(o *routerOptions) {
+		o.tokens = v
+	}
+}
+
+// CORS lets the browser origins in o call the API.
+func CORS(o middleware.CORSOptions) Option {
+	return func(opts *routerOptions) {
+		opts.cors = &o
+	}
+}
+
+// Compress encodes responses of at least o.MinSize bytes with brotli or gzip
+// for clients that accept it.
+func Compress(o middleware.CompressOptions) Option {
+	return func(opts *routerOptions) {
+		opts.compress = &o
+	}
+}
+
+// MaxBodyBytes rejects request bodies larger than n bytes with 413.
+func MaxBodyBytes(n int64) Option {
+	return func(o *routerOptions) {
+		o.maxBodyBytes = n
+	}
+}
+
+// RateLimit limits the API routes per caller with buckets kept in store.
+// routes is keyed by "METHOD /path" without the version prefix; other routes
+// get def, which leaves them unlimited when zero.
+func RateLimit(store ratelimit.Store, def ratelimit.Limit, routes map[string]ratelimit.Limit) Option {
+	return func(o *routerOptions) {
+		o.rateLimit = &rateLimit{store, def, routes}
+	}
+}
+
+// limit returns the rate limiter of the routes mounted under prefix, or a
+// pass-through when rate limiting is off.
+func (o *routerOptions) limit(prefix string) gin.HandlerFunc {
+	if o.rateLimit == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.RateLimit(o.rateLimit.store, o.rateLimit.def, o.rateLimit.routes, prefix)
+}
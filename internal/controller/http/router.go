@@ -0,0 +1,84 @@
+// Package http mounts the API versions and the routes they share on one engine.
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/controller/http/openapi"
+	v1 "backend-test/internal/controller/http/v1"
+	"backend-test/pkg/health"
+	"backend-test/pkg/logger"
+)
+
+// NewRouter -.
+// Swagger spec:
+// @title       Business API
+// @description Create, update, delete and search business listings.
+// @version     1.0
+// @host        localhost:8080
+// @BasePath    /v1
+// The OpenAPI 3 document is built from the Document functions of each API
+// version and served at /openapi.json.
+func NewRouter(handler *gin.Engine, l logger.Interface, b usecase.Business, k usecase.APIKey, cl usecase.Claim, hc *health.Health, opts ...Option) {
+	var o routerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Options
+	// Let c.Done() and c.Err() follow the request context, so handlers passing
+	// c down as a context.Context see client disconnects and deadlines.
+	handler.ContextWithFallback = true
+	handler.Use(gin.Logger())
+	handler.Use(gin.Recovery())
+	handler.Use(middleware.Metrics())
+	handler.Use(middleware.Tracing())
+	handler.Use(middleware.RequestID())
+	handler.Use(middleware.Logger(l))
+	handler.Use(middleware.SecurityHeaders())
+	if o.cors != nil {
+		handler.Use(middleware.CORS(*o.cors))
+	}
+	if o.compress != nil {
+		handler.Use(middleware.Compress(*o.compress))
+	}
+	if o.maxBodyBytes > 0 {
+		handler.Use(middleware.BodyLimit(o.maxBodyBytes))
+	}
+
+	spec := openapi.New(openapi.Info{
+		Title:       "Business API",
+		Description: "Create, update, delete and search business listings.",
+		Version:     "1.0",
+	})
+
+	// K8s probes
+	newHealthRoutes(handler, hc)
+	documentHealthRoutes(spec)
+
+	// Prometheus metrics
+	handler.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Routers
+	// Each version gets its own group; middleware shared by all versions is
+	// added with handler.Use above. A v2 package mounts the same way:
+	//   v2.NewRoutes(handler.Group("/v2"), ...)
+	auth := middleware.Authenticate(k, o.tokens)
+
+	v1.NewRoutes(handler.Group("/v1", auth, o.limit("/v1")), b, k, cl, l, o.publicRead)
+	v1.Document(spec, "/v1", false, o.publicRead)
+
+	if o.legacyRoutes {
+		v1.NewRoutes(handler.Group("/", middleware.Deprecated("/v1"), auth, o.limit("")), b, k, cl, l, o.publicRead)
+		v1.Document(spec, "", true, o.publicRead)
+	}
+
+	// OpenAPI spec and Swagger UI
+	handler.GET("/openapi.json", spec.Handler)
+	if o.swaggerUI {
+		handler.GET("/swagger", openapi.SwaggerUI)
+	}
+}
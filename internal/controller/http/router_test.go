@@ -0,0 +1,690 @@
+package http_test
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/business/usecase"
+	controller "backend-test/internal/controller/http"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/entity"
+	"backend-test/pkg/health"
+	"backend-test/pkg/jwtauth"
+	"backend-test/pkg/logger"
+	"backend-test/pkg/ratelimit"
+)
+
+// _undocumented are served by the router but are not part of the API.
+var _undocumented = map[string]bool{
+	"GET /metrics":      true,
+	"GET /openapi.json": true,
+	"GET /swagger":      true,
+}
+
+type spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func newRouter(t *testing.T, opts ...controller.Option) (*gin.Engine, []byte) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	// As in app.Run with the default config: no proxy is trusted.
+	if err := handler.SetTrustedProxies(nil); err != nil {
+		t.Fatalf("SetTrustedProxies: %v", err)
+	}
+	controller.NewRouter(handler, logger.New("error"), nil, fakeKeys{}, nil, health.New(), append([]controller.Option{
+		controller.SwaggerUI(true),
+		controller.LegacyRoutes(true),
+		controller.PublicRead(true),
+		controller.Tokens(fakeTokens{}),
+	}, opts...)...)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json = %d", w.Code)
+	}
+
+	return handler, w.Body.Bytes()
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	handler, body := newRouter(t)
+
+	var s spec
+	if err := json.Unmarshal(body, &s); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	param := regexp.MustCompile(`[:*]([^/]+)`)
+
+	routes := map[string]bool{}
+	for _, r := range handler.Routes() {
+		key := r.Method + " " + r.Path
+		if _undocumented[key] {
+			continue
+		}
+		routes[r.Method+" "+param.ReplaceAllString(r.Path, "{$1}")] = true
+	}
+
+	documented := map[string]bool{}
+	for path, item := range s.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	if missing := diff(routes, documented); len(missing) > 0 {
+		t.Errorf("routes missing from /openapi.json: %v", missing)
+	}
+	if stale := diff(documented, routes); len(stale) > 0 {
+		t.Errorf("/openapi.json documents routes that do not exist: %v", stale)
+	}
+}
+
+func TestOpenAPIReferencesResolve(t *testing.T) {
+	_, body := newRouter(t)
+
+	var s spec
+	if err := json.Unmarshal(body, &s); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	refs := regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`).FindAllSubmatch(body, -1)
+	if len(refs) == 0 {
+		t.Fatal("spec has no schema references")
+	}
+	for _, ref := range refs {
+		if _, ok := s.Components.Schemas[string(ref[1])]; !ok {
+			t.Errorf("unresolved reference to schema %s", ref[1])
+		}
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	_, body := newRouter(t)
+
+	var s spec
+	if err := json.Unmarshal(body, &s); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	add := s.Components.Schemas["AddBusinessRequest"]
+	required := append([]string(nil), add.Required...)
+	sort.Strings(required)
+	if got, want := strings.Join(required, ","), "alias,close_time,open_time,price"; got != want {
+		t.Errorf("AddBusinessRequest required = %s, want %s", got, want)
+	}
+
+	business := s.Components.Schemas["Business"]
+	for _, field := range []string{"id", "alias", "categories", "coordinates", "location", "distance", "attributes"} {
+		if _, ok := business.Properties[field]; !ok {
+			t.Errorf("Business schema lacks %q", field)
+		}
+	}
+	for _, hidden := range []string{"UUID", "open_time", "DeletedAt"} {
+		if _, ok := business.Properties[hidden]; ok {
+			t.Errorf("Business schema exposes %q, which is not serialized", hidden)
+		}
+	}
+}
+
+func TestSwaggerUI(t *testing.T) {
+	handler, _ := newRouter(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/openapi.json") {
+		t.Errorf("GET /swagger = %d, want the Swagger UI page", w.Code)
+	}
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	handler, _ := newRouter(t)
+
+	tests := []struct {
+		path       string
+		deprecated bool
+	}{
+		{"/v1/business/search", false},
+		{"/business/search", true},
+		{"/livez", false},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if w.Code == http.StatusNotFound {
+			t.Errorf("GET %s = 404", tt.path)
+		}
+
+		if got := w.Header().Get("Deprecation") == "true"; got != tt.deprecated {
+			t.Errorf("GET %s Deprecation header = %v, want %v", tt.path, got, tt.deprecated)
+		}
+		if tt.deprecated {
+			if link := w.Header().Get("Link"); link != `</v1`+tt.path+`>; rel="successor-version"` {
+				t.Errorf("GET %s Link = %q", tt.path, link)
+			}
+		}
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	handler, _ := newRouter(t)
+
+	tests := []struct {
+		name, sent string
+		echoed     bool
+	}{
+		{"generated", "", false},
+		{"accepted", "req-123", true},
+		{"malformed", "bad id\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// An unparsable limit fails before the use case is called.
+			r := httptest.NewRequest(http.MethodGet, "/v1/business/search?limit=x", nil)
+			if tt.sent != "" {
+				r.Header.Set("X-Request-ID", tt.sent)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			id := w.Header().Get("X-Request-ID")
+			if id == "" || (id == tt.sent) != tt.echoed {
+				t.Fatalf("X-Request-ID = %q, sent %q", id, tt.sent)
+			}
+
+			var body struct {
+				RequestID string `json:"request_id"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.RequestID != id {
+				t.Errorf("error envelope request_id = %q, want %q (%v)", body.RequestID, id, err)
+			}
+		})
+	}
+}
+
+// fakeKeys authenticates the keys named after their scope, e.g. "bk_read".
+type fakeKeys struct{}
+
+func (fakeKeys) Issue(context.Context, string, []string) (entity.APIKey, string, error) {
+	return entity.APIKey{}, "", errors.New("not implemented")
+}
+
+func (fakeKeys) Revoke(context.Context, uint) error { return entity.ErrNotFound }
+
+func (fakeKeys) List(context.Context) ([]entity.APIKey, error) { return nil, nil }
+
+func (fakeKeys) Authenticate(_ context.Context, key string) (entity.Principal, error) {
+	scope := strings.TrimPrefix(key, "bk_")
+	if !entity.ValidScope(scope) {
+		return entity.Principal{}, entity.ErrUnauthenticated
+	}
+	return entity.Principal{Subject: key, Scopes: []string{scope}}, nil
+}
+
+// fakeTokens accepts the tokens "editor" and "user", a user without roles.
+type fakeTokens struct{}
+
+func (fakeTokens) Verify(token string) (jwtauth.Claims, error) {
+	switch token {
+	case "editor":
+		return jwtauth.Claims{Subject: "1", Roles: []string{entity.RoleEditor}}, nil
+	case "user":
+		return jwtauth.Claims{Subject: "2"}, nil
+	default:
+		return jwtauth.Claims{}, jwtauth.ErrInvalidToken
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	handler, _ := newRouter(t)
+
+	tests := []struct {
+		method, path, key, token string
+		want                     int
+	}{
+		// Search is public; the unparsable limit fails after authorization.
+		{http.MethodGet, "/v1/business/search?limit=x", "", "", http.StatusInternalServerError},
+		{http.MethodGet, "/v1/business/search?limit=x", "bk_bogus", "", http.StatusUnauthorized},
+		{http.MethodPost, "/v1/business/", "", "", http.StatusUnauthorized},
+		{http.MethodPost, "/v1/business/", "bk_read", "", http.StatusForbidden},
+		// A write key gets through to the handler, which rejects the empty body.
+		{http.MethodPost, "/v1/business/", "bk_write", "", http.StatusInternalServerError},
+		{http.MethodDelete, "/business/1", "bk_read", "", http.StatusForbidden},
+		{http.MethodGet, "/v1/admin/api-keys/", "bk_write", "", http.StatusForbidden},
+		{http.MethodGet, "/v1/admin/api-keys/", "bk_admin", "", http.StatusOK},
+		{http.MethodDelete, "/v1/admin/api-keys/7", "bk_admin", "", http.StatusNotFound},
+		{http.MethodPost, "/v1/business/", "", "forged", http.StatusUnauthorized},
+		{http.MethodPost, "/v1/business/", "", "user", http.StatusForbidden},
+		{http.MethodPost, "/v1/business/", "", "editor", http.StatusInternalServerError},
+		// Any user may try an update; the use case checks ownership.
+		{http.MethodPut, "/v1/business/1", "", "user", http.StatusInternalServerError},
+		{http.MethodPut, "/v1/business/1", "", "", http.StatusUnauthorized},
+		{http.MethodPost, "/v1/business/1/claims", "", "", http.StatusUnauthorized},
+		{http.MethodGet, "/v1/admin/claims/", "", "user", http.StatusForbidden},
+		{http.MethodPost, "/v1/admin/claims/1/approve", "bk_write", "", http.StatusForbidden},
+		{http.MethodGet, "/v1/admin/business/1", "", "editor", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.key != "" {
+			r.Header.Set("X-API-Key", tt.key)
+		}
+		if tt.token != "" {
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("%s %s with key %q token %q = %d, want %d: %s", tt.method, tt.path, tt.key, tt.token, w.Code, tt.want, w.Body)
+		}
+	}
+}
+
+func diff(a, b map[string]bool) []string {
+	var out []string
+	for k := range a {
+		if !b[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestRateLimit(t *testing.T) {
+	handler, _ := newRouter(t, controller.RateLimit(
+		ratelimit.NewMemoryStore(),
+		ratelimit.Limit{},
+		map[string]ratelimit.Limit{"GET /business/search": {Requests: 2, Per: time.Minute}},
+	))
+
+	get := func(path, key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// The unparsable limit fails in the handler, after the limiter.
+	w := get("/v1/business/search?limit=x", "")
+	if w.Code != http.StatusInternalServerError || w.Header().Get("RateLimit-Limit") != "2" ||
+		w.Header().Get("RateLimit-Remaining") != "1" || w.Header().Get("RateLimit-Policy") != "2;w=60" {
+		t.Fatalf("first request = %d %v, want it through with 1 remaining", w.Code, w.Header())
+	}
+
+	// The legacy route shares the bucket of its /v1 twin.
+	if w := get("/business/search?limit=x", ""); w.Code != http.StatusInternalServerError {
+		t.Fatalf("second request = %d, want it through", w.Code)
+	}
+
+	w = get("/v1/business/search?limit=x", "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "30" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("third request = %d %v, want 429 with Retry-After 30", w.Code, w.Header())
+	}
+	if !strings.Contains(w.Body.String(), entity.ErrRateLimited.Error()) {
+		t.Errorf("body = %s, want the error envelope", w.Body)
+	}
+
+	// Each API key has its own bucket, and unlisted routes are unlimited.
+	if w := get("/v1/business/search?limit=x", "bk_read"); w.Code != http.StatusInternalServerError {
+		t.Errorf("request with a key = %d, want it through", w.Code)
+	}
+	if w := get("/v1/admin/api-keys/", "bk_admin"); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("unlimited route = %d %v, want 200 without RateLimit headers", w.Code, w.Header())
+	}
+}
+
+func TestTrustedProxies(t *testing.T) {
+	limit := func() controller.Option {
+		return controller.RateLimit(
+			ratelimit.NewMemoryStore(),
+			ratelimit.Limit{},
+			map[string]ratelimit.Limit{"GET /business/search": {Requests: 1, Per: time.Minute}},
+		)
+	}
+
+	get := func(handler *gin.Engine, forwardedFor string) int {
+		r := httptest.NewRequest(http.MethodGet, "/v1/business/search?limit=x", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// Without trusted proxies a spoofed X-Forwarded-For does not get the
+	// caller a fresh bucket.
+	handler, _ := newRouter(t, limit())
+	if code := get(handler, "198.51.100.1"); code == http.StatusTooManyRequests {
+		t.Fatalf("first request = %d, want it through", code)
+	}
+	if code := get(handler, "198.51.100.2"); code != http.StatusTooManyRequests {
+		t.Errorf("request with another X-Forwarded-For = %d, want 429 from the shared bucket", code)
+	}
+
+	// Behind a trusted proxy, each forwarded client has its own bucket.
+	handler, _ = newRouter(t, limit())
+	if err := handler.SetTrustedProxies([]string{"192.0.2.0/24"}); err != nil {
+		t.Fatalf("SetTrustedProxies: %v", err)
+	}
+	if code := get(handler, "198.51.100.3"); code == http.StatusTooManyRequests {
+		t.Fatalf("first forwarded request = %d, want it through", code)
+	}
+	if code := get(handler, "198.51.100.4"); code == http.StatusTooManyRequests {
+		t.Errorf("request from another forwarded client = %d, want it through", code)
+	}
+	if code := get(handler, "198.51.100.3"); code != http.StatusTooManyRequests {
+		t.Errorf("second request from the same forwarded client = %d, want 429", code)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	handler, _ := newRouter(t, controller.CORS(middleware.CORSOptions{
+		AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+		MaxAge:         10 * time.Minute,
+	}))
+
+	tests := []struct {
+		name, method, origin, requestMethod string
+		want                                int
+		allowOrigin                         string
+	}{
+		{"Preflight", http.MethodOptions, "https://app.example.com", http.MethodPost, http.StatusNoContent, "https://app.example.com"},
+		{"PreflightSubdomain", http.MethodOptions, "https://eu.example.org", http.MethodGet, http.StatusNoContent, "https://eu.example.org"},
+		{"PreflightApex", http.MethodOptions, "https://example.org", http.MethodGet, http.StatusForbidden, ""},
+		{"PreflightOtherOrigin", http.MethodOptions, "https://evil.test", http.MethodGet, http.StatusForbidden, ""},
+		{"PreflightMethod", http.MethodOptions, "https://app.example.com", http.MethodDelete, http.StatusForbidden, ""},
+		{"Simple", http.MethodGet, "https://app.example.com", "", http.StatusOK, "https://app.example.com"},
+		{"SimpleOtherOrigin", http.MethodGet, "https://evil.test", "", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/v1/admin/api-keys/", nil)
+			r.Header.Set("Origin", tt.origin)
+			r.Header.Set("X-API-Key", "bk_admin")
+			if tt.requestMethod != "" {
+				r.Header.Set("Access-Control-Request-Method", tt.requestMethod)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.want || w.Header().Get("Access-Control-Allow-Origin") != tt.allowOrigin {
+				t.Fatalf("status %d, allow origin %q, want %d, %q", w.Code, w.Header().Get("Access-Control-Allow-Origin"), tt.want, tt.allowOrigin)
+			}
+			if tt.want == http.StatusNoContent {
+				if got := w.Header().Get("Access-Control-Allow-Headers"); got != "Content-Type, X-API-Key" {
+					t.Errorf("allow headers = %q", got)
+				}
+				if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+					t.Errorf("max age = %q, want 600", got)
+				}
+			}
+			if tt.name == "Simple" && !strings.Contains(w.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID") {
+				t.Errorf("expose headers = %q, want X-Request-ID among them", w.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	handler, _ := newRouter(t)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	for header, want := range map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"X-Frame-Options":         "DENY",
+		"Referrer-Policy":         "no-referrer",
+		"Content-Security-Policy": "default-src 'none'; frame-ancestors 'none'",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if got := w.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("Strict-Transport-Security = %q over plain HTTP, want none", got)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger", nil))
+	if got := w.Header().Get("Content-Security-Policy"); !strings.Contains(got, "https://unpkg.com") {
+		t.Errorf("Swagger UI policy = %q, want the CDN allowed", got)
+	}
+}
+
+func TestHealthProbes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	hc := health.New()
+	hc.Register("db", func(context.Context) error { return nil })
+	controller.NewRouter(handler, logger.New("error"), nil, fakeKeys{}, nil, hc)
+
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	if code := get("/readyz"); code != http.StatusOK {
+		t.Errorf("GET /readyz = %d, want 200", code)
+	}
+
+	hc.Shutdown()
+	if code := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("GET /readyz after Shutdown = %d, want 503", code)
+	}
+	if code := get("/livez"); code != http.StatusOK {
+		t.Errorf("GET /livez after Shutdown = %d, want 200", code)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	handler, _ := newRouter(t, controller.MaxBodyBytes(64))
+
+	post := func(body io.Reader, length int64) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/admin/api-keys/", body)
+		r.ContentLength = length
+		r.Header.Set("X-API-Key", "bk_admin")
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	big := strings.Repeat("x", 100)
+	if w := post(strings.NewReader(big), 100); w.Code != http.StatusRequestEntityTooLarge ||
+		!strings.Contains(w.Body.String(), "request body too large") {
+		t.Errorf("large body = %d %s, want 413", w.Code, w.Body)
+	}
+	// A body of unknown length is measured as it is read.
+	if w := post(io.MultiReader(strings.NewReader(big)), -1); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large chunked body = %d, want 413", w.Code)
+	}
+	// Small bodies reach the handler, which rejects the missing fields.
+	if w := post(io.MultiReader(strings.NewReader(`{}`)), -1); w.Code != http.StatusBadRequest {
+		t.Errorf("small chunked body = %d %s, want the handler's 400", w.Code, w.Body)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	handler, doc := newRouter(t, controller.Compress(middleware.CompressOptions{MinSize: 512}))
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 2048)...)
+	handler.GET("/photo", func(c *gin.Context) { c.Data(http.StatusOK, "image/png", png) })
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			r.Header.Set("Accept-Encoding", accept)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	for _, tt := range []struct {
+		accept, want string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip;q=0", ""},
+		{"*", "br"},
+		{"identity", ""},
+	} {
+		w := get("/openapi.json", tt.accept)
+		if got := w.Header().Get("Content-Encoding"); got != tt.want {
+			t.Errorf("Accept-Encoding %q: Content-Encoding = %q, want %q", tt.accept, got, tt.want)
+			continue
+		}
+		if !strings.Contains(w.Header().Get("Vary"), "Accept-Encoding") {
+			t.Errorf("Accept-Encoding %q: Vary = %q, want Accept-Encoding", tt.accept, w.Header().Get("Vary"))
+		}
+
+		var body io.Reader = w.Body
+		switch tt.want {
+		case "gzip":
+			zr, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatalf("gzip.NewReader: %v", err)
+			}
+			body = zr
+		case "br":
+			body = brotli.NewReader(w.Body)
+		}
+		got, err := io.ReadAll(body)
+		if err != nil {
+			t.Fatalf("Accept-Encoding %q: decode: %v", tt.accept, err)
+		}
+		if string(got) != string(doc) {
+			t.Errorf("Accept-Encoding %q: decoded body differs from the identity body", tt.accept)
+		}
+	}
+
+	// Small bodies and compressed formats are sent as is.
+	if w := get("/livez", "gzip"); w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("GET /livez is compressed, want it below the threshold")
+	}
+	if w := get("/photo", "gzip, br"); w.Header().Get("Content-Encoding") != "" || w.Body.Len() != len(png) {
+		t.Errorf("GET /photo = %q, %d bytes, want the image as is", w.Header().Get("Content-Encoding"), w.Body.Len())
+	}
+}
+
+// fakeBusiness serves one business and records the fieldset its callers pass.
+type fakeBusiness struct {
+	usecase.Business
+	fieldset entity.Fieldset
+}
+
+func (f *fakeBusiness) business(ctx context.Context) entity.Business {
+	f.fieldset = entity.FieldsetFrom(ctx)
+	return entity.Business{
+		ID: "b1", Name: "Kopi", Rating: 4,
+		Coordinates: entity.Cordinates{Latitude: -6.2, Longitude: 106.8},
+		Categories:  []entity.Categories{{Alias: "coffee", Name: "Coffee"}},
+	}
+}
+
+func (f *fakeBusiness) Read(ctx context.Context, _ string) (entity.Business, error) {
+	return f.business(ctx), nil
+}
+
+func (f *fakeBusiness) Search(ctx context.Context, _ entity.SearchBusinessParam) ([]entity.Business, error) {
+	return []entity.Business{f.business(ctx)}, nil
+}
+
+func TestFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	b := &fakeBusiness{}
+	controller.NewRouter(handler, logger.New("error"), b, fakeKeys{}, nil, health.New(), controller.PublicRead(true))
+
+	get := func(path string) (int, json.RawMessage) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var body struct {
+			Data json.RawMessage `json:"data"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body.Data
+	}
+	keys := func(data json.RawMessage) []string {
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			t.Fatalf("data %s is not an object: %v", data, err)
+		}
+		var names []string
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	code, data := get("/v1/business/b1?fields=id,name,rating,coordinates,name")
+	if code != http.StatusOK {
+		t.Fatalf("GET with fields = %d", code)
+	}
+	if got, want := keys(data), []string{"coordinates", "id", "name", "rating"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+	if b.fieldset.Has("categories") {
+		t.Errorf("fieldset passed on = %v, want categories left out", b.fieldset)
+	}
+
+	code, data = get("/v1/business/search?limit=10&fields=id,categories")
+	var results []json.RawMessage
+	if err := json.Unmarshal(data, &results); code != http.StatusOK || err != nil || len(results) != 1 {
+		t.Fatalf("search with fields = %d %s", code, data)
+	}
+	if got := keys(results[0]); strings.Join(got, ",") != "categories,id" {
+		t.Errorf("search fields = %v, want categories,id", got)
+	}
+	if !b.fieldset.Has("categories") {
+		t.Errorf("fieldset passed on = %v, want categories", b.fieldset)
+	}
+
+	if _, data = get("/v1/business/b1"); len(keys(data)) < 10 || b.fieldset != nil {
+		t.Errorf("GET without fields = %v with fieldset %v, want every field", keys(data), b.fieldset)
+	}
+	if code, _ = get("/v1/business/b1?fields=id,owner_id"); code != http.StatusBadRequest {
+		t.Errorf("GET with a hidden field = %d, want 400", code)
+	}
+}
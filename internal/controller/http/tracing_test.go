@@ -0,0 +1,157 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/business/usecase/repo"
+	controller "backend-test/internal/controller/http"
+	"backend-test/internal/db"
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/internal/db/migrate"
+	"backend-test/internal/entity"
+	"backend-test/pkg/health"
+	"backend-test/pkg/logger"
+)
+
+const (
+	_traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	_parentSpanID = "00f067aa0ba902b7"
+)
+
+// newTracedRouter wires a router over a traced use case and a traced SQLite
+// database, as app.Run does, with spans going to the returned recorder.
+func newTracedRouter(t *testing.T) (*gin.Engine, *tracetest.SpanRecorder, func(sql string)) {
+	t.Helper()
+
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	conn, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close(conn) })
+	m, err := migrate.New(conn)
+	if err != nil {
+		t.Fatalf("migrate.New: %v", err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if err := db.RegisterTracing(conn); err != nil {
+		t.Fatalf("RegisterTracing: %v", err)
+	}
+
+	l := logger.New("error")
+	business := repo.NewBusinessRepo(conn, l)
+	if err := business.Create(context.Background(), entity.Business{ID: "b1", Alias: "b1", Price: "$"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	b := usecase.NewTracedBusiness(usecase.NewBusinessUseCase(business, repo.NewAuditRepo(conn), repo.NewGormUnitOfWork(conn), l))
+
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	controller.NewRouter(handler, l, b, fakeKeys{}, nil, health.New(), controller.PublicRead(true))
+
+	exec := func(sql string) {
+		if err := conn.Exec(sql).Error; err != nil {
+			t.Fatalf("Exec: %v", err)
+		}
+	}
+	return handler, rec, exec
+}
+
+// traced serves a request carrying a traceparent header and returns the spans
+// of its trace that ended while serving it.
+func traced(t *testing.T, handler *gin.Engine, rec *tracetest.SpanRecorder, path string) []sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	before := len(rec.Ended())
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	r.Header.Set("traceparent", "00-"+_traceID+"-"+_parentSpanID+"-01")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	var spans []sdktrace.ReadOnlySpan
+	for _, s := range rec.Ended()[before:] {
+		if s.SpanContext().TraceID().String() == _traceID {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, match func(sdktrace.ReadOnlySpan) bool) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	for _, s := range spans {
+		if match(s) {
+			return s
+		}
+	}
+	t.Fatalf("no matching span among %d", len(spans))
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	handler, rec, exec := newTracedRouter(t)
+
+	spans := traced(t, handler, rec, "/v1/business/b1")
+	server := findSpan(t, spans, func(s sdktrace.ReadOnlySpan) bool { return s.SpanKind() == trace.SpanKindServer })
+	if server.Name() != "GET /v1/business/:id" {
+		t.Errorf("server span = %q, want the route template", server.Name())
+	}
+	if p := server.Parent(); !p.IsRemote() || p.SpanID().String() != _parentSpanID {
+		t.Errorf("server span parent = %v, want the traceparent span %s", p.SpanID(), _parentSpanID)
+	}
+
+	uc := findSpan(t, spans, func(s sdktrace.ReadOnlySpan) bool { return s.Name() == "BusinessUseCase.Read" })
+	if uc.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("use case span parent = %v, want the server span %v", uc.Parent().SpanID(), server.SpanContext().SpanID())
+	}
+
+	query := findSpan(t, spans, func(s sdktrace.ReadOnlySpan) bool { return strings.HasPrefix(s.Name(), "gorm.") })
+	if query.Parent().SpanID() != uc.SpanContext().SpanID() {
+		t.Errorf("GORM span %q parent = %v, want the use case span", query.Name(), query.Parent().SpanID())
+	}
+	for _, s := range spans {
+		if s.Status().Code == codes.Error {
+			t.Errorf("span %q of a successful read has status %v", s.Name(), s.Status())
+		}
+	}
+
+	// A statement failing in the database fails every span up to the server.
+	exec("DROP TABLE business_categories")
+	spans = traced(t, handler, rec, "/v1/business/b1")
+	for _, name := range []string{"GET /v1/business/:id", "BusinessUseCase.Read"} {
+		name := name
+		s := findSpan(t, spans, func(s sdktrace.ReadOnlySpan) bool { return s.Name() == name })
+		if s.Status().Code != codes.Error {
+			t.Errorf("span %q status = %v, want an error", name, s.Status())
+		}
+	}
+	failed := findSpan(t, spans, func(s sdktrace.ReadOnlySpan) bool {
+		return strings.HasPrefix(s.Name(), "gorm.") && s.Status().Code == codes.Error
+	})
+	if !strings.Contains(failed.Status().Description, "no such table") {
+		t.Errorf("GORM span status = %q, want the database error", failed.Status().Description)
+	}
+}
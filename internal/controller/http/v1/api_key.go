@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+type apiKeyRoutes struct {
+	k usecase.APIKey
+	l logger.Interface
+}
+
+func newAPIKeyRoutes(handler *gin.RouterGroup, k usecase.APIKey, l logger.Interface) {
+	r := &apiKeyRoutes{k, l}
+
+	h := handler.Group("/admin/api-keys", middleware.RequireScope(entity.ScopeAdmin))
+	{
+		h.POST("/", r.issueAPIKey)
+		h.GET("/", r.listAPIKeys)
+		h.DELETE("/:id", r.revokeAPIKey)
+	}
+}
+
+type issueAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+type issuedAPIKey struct {
+	entity.APIKey
+	// Key is the plaintext key. It is only returned once.
+	Key string `json:"key"`
+}
+
+func (r *apiKeyRoutes) issueAPIKey(c *gin.Context) {
+	var req issueAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		r.log(c).Error(err)
+		errorJSON(c, http.StatusBadRequest, err)
+		return
+	}
+
+	k, plain, err := r.k.Issue(c, req.Name, req.Scopes)
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "OK", "data": issuedAPIKey{k, plain}})
+}
+
+func (r *apiKeyRoutes) listAPIKeys(c *gin.Context) {
+	keys, err := r.k.List(c)
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK", "data": keys})
+}
+
+func (r *apiKeyRoutes) revokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		errorJSON(c, http.StatusNotFound, entity.ErrNotFound)
+		return
+	}
+
+	if err := r.k.Revoke(c, uint(id)); err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK", "data": "api key revoked"})
+}
+
+// log returns the request-scoped logger, falling back to r.l.
+func (r *apiKeyRoutes) log(c *gin.Context) logger.Interface {
+	return logger.FromContext(c, r.l)
+}
@@ -10,6 +10,7 @@ import (
 	"backend-test/pkg/logger"
 
 	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
 
 	"backend-test/internal/entity"
 )
@@ -20,17 +21,22 @@ type businessRoutes struct {
 	v *validator.Validate
 }
 
-func newBusinessRoutes(handler *gin.RouterGroup, t usecase.Business, l logger.Interface) {
+func newBusinessRoutes(handler *gin.RouterGroup, t usecase.Business, l logger.Interface, read gin.HandlerFunc) {
 	r := &businessRoutes{t, l, validator.New()}
 
+	write := middleware.RequireScope(entity.ScopeWrite)
+	// Owners may update their own businesses without the write scope; the use
+	// case checks ownership, so the route only needs a known caller.
+	authenticated := middleware.RequireScope(entity.ScopeRead)
+
 	h := handler.Group("/business")
 	{
-		h.POST("/", r.addBusiness)
-		h.PUT("/:id", r.updateBusiness)
-		h.DELETE("/:id", r.deleteBusiness)
+		h.POST("/", write, r.addBusiness)
+		h.PUT("/:id", authenticated, r.updateBusiness)
+		h.DELETE("/:id", write, r.deleteBusiness)
 
-		h.GET("/:id", r.getBusiness)
-		h.GET("/search", r.searchBusiness)
+		h.GET("/:id", read, r.getBusiness)
+		h.GET("/search", read, r.searchBusiness)
 
 	}
 }
@@ -55,10 +61,8 @@ func (r *businessRoutes) addBusiness(c *gin.Context) {
 	var req addBusinessRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -90,11 +94,8 @@ func (r *businessRoutes) addBusiness(c *gin.Context) {
 			Transactions: datatypes.JSONType[[]string]{Data: []string{}},
 			URL:          req.URL},
 	); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, errorStatus(err), err)
 		return
 	}
 
@@ -122,11 +123,8 @@ func (r *businessRoutes) updateBusiness(c *gin.Context) {
 	paramId := c.Param("id")
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -155,11 +153,8 @@ func (r *businessRoutes) updateBusiness(c *gin.Context) {
 		Attributes:   datatypes.JSONType[[]string]{Data: att},
 		Transactions: datatypes.JSONType[[]string]{Data: []string{}},
 		URL:          req.URL}); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, errorStatus(err), err)
 		return
 	}
 
@@ -170,11 +165,8 @@ func (r *businessRoutes) deleteBusiness(c *gin.Context) {
 	paramId := c.Param("id")
 
 	if err := r.b.Delete(c, paramId); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, errorStatus(err), err)
 		return
 	}
 
@@ -184,26 +176,27 @@ func (r *businessRoutes) deleteBusiness(c *gin.Context) {
 func (r *businessRoutes) getBusiness(c *gin.Context) {
 	paramId := c.Param("id")
 
+	fields, err := withFieldset(c, c.Query("fields"))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err)
+		return
+	}
+
 	business, err := r.b.Read(c, paramId)
 	if err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, errorStatus(err), err)
 		return
 	}
 
-	c.JSON(200, gin.H{"status": "OK", "data": business})
+	c.JSON(200, gin.H{"status": "OK", "data": selectFields(business, fields)})
 }
 
 func (r *businessRoutes) searchBusiness(c *gin.Context) {
 	var q entity.SearchBusinessQueryParam
 	if err := c.ShouldBindQuery(&q); err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, http.StatusInternalServerError, err)
 		return
 	}
 	sp := entity.SearchBusinessParam{
@@ -211,19 +204,41 @@ func (r *businessRoutes) searchBusiness(c *gin.Context) {
 		Offset:     q.Offset,
 		Categories: q.Categories(),
 		Attributes: q.Attributes(),
+		Radius:     q.Radius,
+		Latitude:   q.Latitude,
+		Longitude:  q.Longitude,
 		Price:      q.Price,
 		OpenAt:     q.OpenAt,
 		OpenNow:    q.OpenNow,
 	}
+	fields, err := withFieldset(c, q.Fields)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err)
+		return
+	}
+
 	businesses, err := r.b.Search(c, sp)
 	if err != nil {
-		r.l.Error(err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "ERROR",
-			"error":  err.Error(),
-		})
+		r.log(c).Error(err)
+		errorJSON(c, errorStatus(err), err)
 		return
 	}
 
-	c.JSON(200, gin.H{"status": "OK", "data": businesses, "length": len(businesses)})
+	_searchResults.Observe(float64(len(businesses)))
+
+	var data interface{} = businesses
+	if fields != nil {
+		selected := make([]interface{}, 0, len(businesses))
+		for _, b := range businesses {
+			selected = append(selected, selectFields(b, fields))
+		}
+		data = selected
+	}
+
+	c.JSON(200, gin.H{"status": "OK", "data": data, "length": len(businesses)})
+}
+
+// log returns the request-scoped logger, falling back to r.l.
+func (r *businessRoutes) log(c *gin.Context) logger.Interface {
+	return logger.FromContext(c, r.l)
 }
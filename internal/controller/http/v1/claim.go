@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+type claimRoutes struct {
+	c usecase.Claim
+	b usecase.Business
+	l logger.Interface
+}
+
+func newClaimRoutes(handler *gin.RouterGroup, c usecase.Claim, b usecase.Business, l logger.Interface) {
+	r := &claimRoutes{c, b, l}
+
+	// Any known caller may claim a business; the use case ties the claim to
+	// the caller's subject.
+	handler.POST("/business/:id/claims", middleware.RequireScope(entity.ScopeRead), r.submitClaim)
+
+	admin := handler.Group("/admin", middleware.RequireScope(entity.ScopeAdmin))
+	{
+		admin.GET("/claims/", r.listClaims)
+		admin.POST("/claims/:id/approve", r.approveClaim)
+		admin.POST("/claims/:id/reject", r.rejectClaim)
+
+		admin.GET("/business/:id", r.getAdminBusiness)
+	}
+}
+
+type submitClaimRequest struct {
+	Message string `json:"message"`
+}
+
+// adminBusiness is the admin view of a business, which also shows its owner.
+type adminBusiness struct {
+	entity.Business
+	OwnerID string `json:"owner_id"`
+}
+
+func (r *claimRoutes) submitClaim(c *gin.Context) {
+	var req submitClaimRequest
+	// The message is optional, so an empty body is fine.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			r.log(c).Error(err)
+			errorJSON(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	claim, err := r.c.Submit(c, c.Param("id"), req.Message)
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"status": "OK", "data": claim})
+}
+
+func (r *claimRoutes) listClaims(c *gin.Context) {
+	claims, err := r.c.List(c, c.Query("status"))
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK", "data": claims})
+}
+
+func (r *claimRoutes) approveClaim(c *gin.Context) {
+	r.review(c, r.c.Approve)
+}
+
+func (r *claimRoutes) rejectClaim(c *gin.Context) {
+	r.review(c, r.c.Reject)
+}
+
+func (r *claimRoutes) review(c *gin.Context, fn func(ctx context.Context, id uint) (entity.BusinessClaim, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		errorJSON(c, http.StatusNotFound, entity.ErrNotFound)
+		return
+	}
+
+	claim, err := fn(c, uint(id))
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK", "data": claim})
+}
+
+func (r *claimRoutes) getAdminBusiness(c *gin.Context) {
+	business, err := r.b.Read(c, c.Param("id"))
+	if err != nil {
+		errorJSON(c, errorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK", "data": adminBusiness{business, business.OwnerID}})
+}
+
+// log returns the request-scoped logger, falling back to r.l.
+func (r *claimRoutes) log(c *gin.Context) logger.Interface {
+	return logger.FromContext(c, r.l)
+}
@@ -2,12 +2,22 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/entity"
 )
 
+// _statusClientClosedRequest is the de facto status (from nginx) for requests
+// the client abandoned before a response was written.
+const _statusClientClosedRequest = 499
+
 type response struct {
 	Error string `json:"error" example:"message"`
 }
@@ -16,6 +26,16 @@ func errorResponse(c *gin.Context, code int, msg string) {
 	c.AbortWithStatusJSON(code, response{msg})
 }
 
+// errorJSON writes the error envelope, including the request ID so clients can
+// quote it when reporting a problem.
+func errorJSON(c *gin.Context, code int, err error) {
+	c.JSON(code, gin.H{
+		"status":     "ERROR",
+		"error":      err.Error(),
+		"request_id": middleware.GetRequestID(c),
+	})
+}
+
 func parseError(err error) []string {
 	if validationErrs, ok := err.(validator.ValidationErrors); ok {
 		errorMessages := make([]string, len(validationErrs))
@@ -31,3 +51,31 @@ func parseError(err error) []string {
 	}
 	return nil
 }
+
+// errorStatus maps usecase errors onto HTTP status codes.
+func errorStatus(err error) int {
+	var validationErrs validator.ValidationErrors
+
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrCanceled):
+		return _statusClientClosedRequest
+	case errors.Is(err, entity.ErrTimeout):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, entity.ErrUnauthenticated):
+		return http.StatusUnauthorized
+	case errors.Is(err, entity.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrInvalidScope), errors.Is(err, usecase.ErrInvalidClaimStatus):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrClaimReviewed):
+		return http.StatusConflict
+	case errors.As(err, &validationErrs):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
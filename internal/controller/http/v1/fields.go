@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-test/internal/entity"
+)
+
+// _businessFields maps the JSON names of the serialized fields of
+// entity.Business to their field index.
+var _businessFields = jsonFields(reflect.TypeOf(entity.Business{}))
+
+func jsonFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = i
+	}
+	return fields
+}
+
+// businessFieldNames lists the names the fields parameter accepts, for docs
+// and errors.
+func businessFieldNames() []string {
+	names := make([]string, 0, len(_businessFields))
+	for name := range _businessFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// withFieldset parses the comma-separated fields query parameter and stores
+// it in the request context, so the repository skips loading what will not
+// be serialized. The fieldset is nil, for every field, without the parameter.
+func withFieldset(c *gin.Context, raw string) (entity.Fieldset, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	fields := entity.Fieldset{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || fields.Has(name) {
+			continue
+		}
+		if _, ok := _businessFields[name]; !ok {
+			return nil, fmt.Errorf("unknown field %q in fields, want some of %s", name, strings.Join(businessFieldNames(), ", "))
+		}
+		fields = append(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	c.Request = c.Request.WithContext(entity.WithFieldset(c.Request.Context(), fields))
+	return fields, nil
+}
+
+// selectFields returns b limited to the fields in f, or b itself when f is
+// nil.
+func selectFields(b entity.Business, f entity.Fieldset) interface{} {
+	if f == nil {
+		return b
+	}
+
+	v := reflect.ValueOf(b)
+	out := make(map[string]interface{}, len(f))
+	for _, name := range f {
+		out[name] = v.Field(_businessFields[name]).Interface()
+	}
+	return out
+}
@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var _searchResults = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "business_search_results",
+	Help:    "Number of businesses returned by a search.",
+	Buckets: []float64{0, 1, 5, 10, 20, 50, 100},
+})
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"backend-test/internal/business/usecase"
+	"backend-test/internal/entity"
+	"backend-test/pkg/logger"
+)
+
+// threeResults answers every search with three businesses.
+type threeResults struct {
+	usecase.Business
+}
+
+func (threeResults) Search(context.Context, entity.SearchBusinessParam) ([]entity.Business, error) {
+	return []entity.Business{{ID: "a"}, {ID: "b"}, {ID: "c"}}, nil
+}
+
+func TestSearchResultsMetric(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(_searchResults)
+
+	gin.SetMode(gin.TestMode)
+	handler := gin.New()
+	newBusinessRoutes(handler.Group("/v1"), threeResults{}, logger.New("error"), func(c *gin.Context) { c.Next() })
+
+	observed := func() (uint64, float64) {
+		families, err := reg.Gather()
+		if err != nil {
+			t.Fatalf("Gather: %v", err)
+		}
+		for _, f := range families {
+			if f.GetName() == "business_search_results" {
+				h := f.GetMetric()[0].GetHistogram()
+				return h.GetSampleCount(), h.GetSampleSum()
+			}
+		}
+		t.Fatalf("no business_search_results histogram")
+		return 0, 0
+	}
+	count, sum := observed()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/business/search?limit=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /v1/business/search = %d %s", w.Code, w.Body)
+	}
+
+	if gotCount, gotSum := observed(); gotCount != count+1 || gotSum != sum+3 {
+		t.Errorf("histogram = %d observations summing %v, want one more of 3 over %d, %v", gotCount, gotSum, count, sum)
+	}
+}
@@ -0,0 +1,282 @@
+package v1
+
+import (
+	"net/http"
+
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/controller/http/openapi"
+	"backend-test/internal/entity"
+)
+
+// _fieldsDescription documents the fields query parameter of reads and
+// searches.
+const _fieldsDescription = "Comma-separated top-level fields to return, such as id,name,rating,coordinates. " +
+	"All fields when omitted. Categories are only loaded when listed."
+
+// Document describes the routes registered by NewRoutes under prefix.
+func Document(s *openapi.Spec, prefix string, deprecated, publicRead bool) {
+	s.Name("Category", entity.Categories{})
+	s.Name("Coordinates", entity.Cordinates{})
+	s.Name("Location", entity.Location{})
+
+	business := s.Ref("Business", entity.Business{})
+	idParam := openapi.Parameter{Name: "id", In: "path", Required: true, Description: "Public business id.", Schema: &openapi.Schema{Type: "string"}}
+	tags := []string{"business"}
+
+	s.SecurityScheme("apiKey", &openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        middleware.HeaderAPIKey,
+		Description: "API key with the read, write or admin scope. Admin includes write, write includes read.",
+	})
+	s.SecurityScheme("bearer", &openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "Dashboard user token. The admin role acts as the admin scope, editor as write and any other user as read.",
+	})
+
+	// add documents an operation that needs an API key with scope, or none
+	// if scope is empty.
+	add := func(method, path, scope string, op *openapi.Operation) {
+		op.Deprecated = deprecated
+		if deprecated {
+			op.OperationID += "Legacy"
+		}
+		if scope != "" {
+			op.Security = []map[string][]string{{"apiKey": {scope}}, {"bearer": {}}}
+			op.Responses["401"] = docError(s, "Missing or invalid API key or token.")
+			if _, ok := op.Responses["403"]; !ok {
+				op.Responses["403"] = docError(s, "The caller lacks the "+scope+" scope.")
+			}
+		}
+		if op.RequestBody != nil {
+			op.Responses["413"] = docError(s, "Request body larger than http.max_body_bytes.")
+		}
+		s.Add(method, prefix+path, op)
+	}
+
+	read := entity.ScopeRead
+	if publicRead {
+		read = ""
+	}
+
+	add(http.MethodPost, "/business/", entity.ScopeWrite, &openapi.Operation{
+		OperationID: "addBusiness",
+		Summary:     "Create a business",
+		Tags:        tags,
+		RequestBody: &openapi.RequestBody{Required: true, Content: openapi.JSON(s.Ref("AddBusinessRequest", addBusinessRequest{}))},
+		Responses: map[string]*openapi.Response{
+			"200": docMessage("Business created."),
+			"400": docError(s, "Validation failed."),
+			"409": docError(s, "Alias or id already taken."),
+			"500": docError(s, "Malformed body or internal error."),
+		},
+	})
+
+	add(http.MethodPut, "/business/:id", entity.ScopeRead, &openapi.Operation{
+		OperationID: "updateBusiness",
+		Summary:     "Update the non-empty fields of a business and replace its categories",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{idParam},
+		RequestBody: &openapi.RequestBody{Required: true, Content: openapi.JSON(s.Ref("UpdateBusinessRequest", updateBusinessRequest{}))},
+		Responses: map[string]*openapi.Response{
+			"200": docMessage("Business updated."),
+			"403": docError(s, "Only editors and the owner may update the business."),
+			"404": docError(s, "Business not found."),
+			"409": docError(s, "Alias already taken."),
+			"500": docError(s, "Malformed body or internal error."),
+		},
+	})
+
+	add(http.MethodDelete, "/business/:id", entity.ScopeWrite, &openapi.Operation{
+		OperationID: "deleteBusiness",
+		Summary:     "Delete a business",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{idParam},
+		Responses: map[string]*openapi.Response{
+			"200": docMessage("Business deleted."),
+			"403": docError(s, "Only admins may delete businesses."),
+			"404": docError(s, "Business not found."),
+			"500": docError(s, "Internal error."),
+		},
+	})
+
+	add(http.MethodGet, "/business/:id", read, &openapi.Operation{
+		OperationID: "getBusiness",
+		Summary:     "Get a business",
+		Tags:        tags,
+		Parameters: []openapi.Parameter{idParam, {
+			Name: "fields", In: "query", Description: _fieldsDescription, Schema: &openapi.Schema{Type: "string"},
+		}},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "The business.", Content: openapi.JSON(envelope(business, nil))},
+			"400": docError(s, "Unknown field in fields."),
+			"404": docError(s, "Business not found."),
+			"500": docError(s, "Internal error."),
+		},
+	})
+
+	add(http.MethodGet, "/business/search", read, &openapi.Operation{
+		OperationID: "searchBusiness",
+		Summary:     "Search businesses",
+		Tags:        tags,
+		Parameters: s.QueryParameters(entity.SearchBusinessQueryParam{}, map[string]string{
+			"limit":      "Maximum number of results, capped at 100. Omitted or 0 returns no results.",
+			"offset":     "Number of results to skip.",
+			"categories": "Comma-separated category aliases; matches businesses in any of them.",
+			"attributes": "Comma-separated attributes; matches businesses having all of them.",
+			"radius":     "Search radius in meters around latitude/longitude; 0 disables the geo filter.",
+			"latitude":   "Latitude of the geo filter center.",
+			"longitude":  "Longitude of the geo filter center.",
+			"price":      "Price level, the number of characters in the price (1-4).",
+			"open_at":    "Unix timestamp; matches businesses open at its time of day.",
+			"open_now":   "Matches businesses open at the current time of day.",
+			"fields":     _fieldsDescription,
+		}),
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Matching businesses.", Content: openapi.JSON(envelope(
+				&openapi.Schema{Type: "array", Items: business},
+				map[string]*openapi.Schema{"length": {Type: "integer"}},
+			))},
+			"400": docError(s, "Unknown field in fields."),
+			"500": docError(s, "Malformed query or internal error."),
+			"504": docError(s, "Search timed out."),
+		},
+	})
+
+	apiKey := s.Ref("APIKey", entity.APIKey{})
+	keyTags := []string{"admin"}
+
+	add(http.MethodPost, "/admin/api-keys/", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "issueAPIKey",
+		Summary:     "Issue an API key; the plaintext key is returned only once",
+		Tags:        keyTags,
+		RequestBody: &openapi.RequestBody{Required: true, Content: openapi.JSON(s.Ref("IssueAPIKeyRequest", issueAPIKeyRequest{}))},
+		Responses: map[string]*openapi.Response{
+			"201": {Description: "The issued key.", Content: openapi.JSON(envelope(s.Ref("IssuedAPIKey", issuedAPIKey{}), nil))},
+			"400": docError(s, "Missing name or unknown scope."),
+		},
+	})
+
+	add(http.MethodGet, "/admin/api-keys/", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "listAPIKeys",
+		Summary:     "List API keys, revoked ones included",
+		Tags:        keyTags,
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "All keys.", Content: openapi.JSON(envelope(&openapi.Schema{Type: "array", Items: apiKey}, nil))},
+			"500": docError(s, "Internal error."),
+		},
+	})
+
+	add(http.MethodDelete, "/admin/api-keys/:id", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "revokeAPIKey",
+		Summary:     "Revoke an API key",
+		Tags:        keyTags,
+		Parameters:  []openapi.Parameter{{Name: "id", In: "path", Required: true, Description: "API key id.", Schema: &openapi.Schema{Type: "integer"}}},
+		Responses: map[string]*openapi.Response{
+			"200": docMessage("API key revoked."),
+			"404": docError(s, "API key not found."),
+		},
+	})
+
+	claim := s.Ref("BusinessClaim", entity.BusinessClaim{})
+	claimIDParam := openapi.Parameter{Name: "id", In: "path", Required: true, Description: "Claim id.", Schema: &openapi.Schema{Type: "integer"}}
+
+	add(http.MethodPost, "/business/:id/claims", entity.ScopeRead, &openapi.Operation{
+		OperationID: "submitClaim",
+		Summary:     "Claim ownership of a business; an admin approves or rejects the claim",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{idParam},
+		RequestBody: &openapi.RequestBody{Content: openapi.JSON(s.Ref("SubmitClaimRequest", submitClaimRequest{}))},
+		Responses: map[string]*openapi.Response{
+			"201": {Description: "The pending claim.", Content: openapi.JSON(envelope(claim, nil))},
+			"400": docError(s, "Malformed body."),
+			"404": docError(s, "Business not found."),
+			"409": docError(s, "The caller already owns the business or has a pending claim on it."),
+		},
+	})
+
+	add(http.MethodGet, "/admin/claims/", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "listClaims",
+		Summary:     "List business claims, oldest first",
+		Tags:        keyTags,
+		Parameters: []openapi.Parameter{{
+			Name: "status", In: "query", Description: "Only claims in this status.",
+			Schema: &openapi.Schema{Type: "string", Enum: []string{entity.ClaimPending, entity.ClaimApproved, entity.ClaimRejected}},
+		}},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "Matching claims.", Content: openapi.JSON(envelope(&openapi.Schema{Type: "array", Items: claim}, nil))},
+			"400": docError(s, "Unknown status."),
+		},
+	})
+
+	add(http.MethodPost, "/admin/claims/:id/approve", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "approveClaim",
+		Summary:     "Approve a claim, making the claimant the owner and rejecting the other pending claims on the business",
+		Tags:        keyTags,
+		Parameters:  []openapi.Parameter{claimIDParam},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "The approved claim.", Content: openapi.JSON(envelope(claim, nil))},
+			"404": docError(s, "Claim or business not found."),
+			"409": docError(s, "Claim already reviewed."),
+		},
+	})
+
+	add(http.MethodPost, "/admin/claims/:id/reject", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "rejectClaim",
+		Summary:     "Reject a claim",
+		Tags:        keyTags,
+		Parameters:  []openapi.Parameter{claimIDParam},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "The rejected claim.", Content: openapi.JSON(envelope(claim, nil))},
+			"404": docError(s, "Claim not found."),
+			"409": docError(s, "Claim already reviewed."),
+		},
+	})
+
+	add(http.MethodGet, "/admin/business/:id", entity.ScopeAdmin, &openapi.Operation{
+		OperationID: "getAdminBusiness",
+		Summary:     "Get a business with its owner",
+		Tags:        keyTags,
+		Parameters:  []openapi.Parameter{idParam},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "The business and its owner_id.", Content: openapi.JSON(envelope(s.Ref("AdminBusiness", adminBusiness{}), nil))},
+			"404": docError(s, "Business not found."),
+		},
+	})
+}
+
+// envelope is the {"status": "OK", "data": ...} wrapper every handler returns.
+func envelope(data *openapi.Schema, extra map[string]*openapi.Schema) *openapi.Schema {
+	out := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"status": {Type: "string", Enum: []string{"OK"}},
+			"data":   data,
+		},
+		Required: []string{"status", "data"},
+	}
+	for k, v := range extra {
+		out.Properties[k] = v
+	}
+	return out
+}
+
+func docError(s *openapi.Spec, description string) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content: openapi.JSON(s.Ref("ErrorResponse", struct {
+			Status    string `json:"status"`
+			Error     string `json:"error" binding:"required"`
+			RequestID string `json:"request_id"`
+		}{})),
+	}
+}
+
+func docMessage(description string) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content:     openapi.JSON(envelope(&openapi.Schema{Type: "string"}, nil)),
+	}
+}
@@ -3,39 +3,25 @@ package v1
 
 import (
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"backend-test/internal/business/usecase"
+	"backend-test/internal/controller/http/middleware"
+	"backend-test/internal/entity"
 	"backend-test/pkg/logger"
 )
 
-// NewRouter -.
-// Swagger spec:
-// @title       Go Clean Template API
-// @description Using a translation service as an example
-// @version     1.0
-// @host        localhost:8080
-// @BasePath    /v1
-func NewRouter(handler *gin.Engine, l logger.Interface, b usecase.Business) {
-	// Options
-	handler.Use(gin.Logger())
-	handler.Use(gin.Recovery())
+// NewRoutes mounts the v1 API on handler. The caller picks the prefix and the
+// middleware shared with other versions, which must include
+// middleware.Authenticate. Reads need the read scope unless publicRead is
+// set, writes the write scope, and key management and claim review the admin
+// scope.
+func NewRoutes(handler *gin.RouterGroup, b usecase.Business, k usecase.APIKey, cl usecase.Claim, l logger.Interface, publicRead bool) {
+	read := middleware.RequireScope(entity.ScopeRead)
+	if publicRead {
+		read = func(c *gin.Context) { c.Next() }
+	}
 
-	// // Swagger
-	// swaggerHandler := ginSwagger.DisablingWrapHandler(swaggerFiles.Handler, "DISABLE_SWAGGER_HTTP_HANDLER")
-	// handler.GET("/swagger/*any", swaggerHandler)
-
-	// // K8s probe
-	// handler.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
-
-	// Prometheus metrics
-	handler.GET("/metrics", gin.WrapH(promhttp.Handler()))
-
-	// // Routers
-	// h := handler.Group("/bussiness")
-	// {
-	// 	newTranslationRoutes(h, t, l)
-	// }
-	h := handler.Group("/")
-	newBusinessRoutes(h, b, l)
+	newBusinessRoutes(handler, b, l, read)
+	newAPIKeyRoutes(handler, k, l)
+	newClaimRoutes(handler, cl, b, l)
 }
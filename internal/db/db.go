@@ -0,0 +1,133 @@
+// Package db opens the GORM connection for the configured storage driver.
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+
+	"backend-test/config"
+	"backend-test/internal/db/gorm/mysql"
+	"backend-test/internal/db/gorm/postgres"
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/internal/db/instrument"
+	"backend-test/pkg/logger"
+)
+
+// NewGorm opens the database selected by cfg.Storage.Driver and applies the
+// pool settings. Failed attempts are retried with exponential backoff up to
+// cfg.Storage.ConnectRetries times, so the app can start before the database.
+// Statements, including those run while connecting, are logged to l.
+func NewGorm(cfg *config.Config, l logger.Interface) (*gorm.DB, error) {
+	return connect(cfg, l, open, time.Sleep)
+}
+
+// opener opens the database of cfg. Like gorm.Open, it may return a handle
+// along with an error.
+type opener func(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error)
+
+func connect(cfg *config.Config, l logger.Interface, open opener, sleep func(time.Duration)) (*gorm.DB, error) {
+	backoff := cfg.Storage.ConnectBackoff
+
+	for attempt := 1; ; attempt++ {
+		// gorm.Open keeps and fills in the config it is given, so each
+		// attempt gets its own.
+		conn, err := open(cfg, &gorm.Config{Logger: instrument.NewLogger(l, cfg.Storage.SlowQueryThreshold)})
+		if err == nil {
+			if err = configurePool(conn, cfg.Storage); err == nil {
+				return conn, nil
+			}
+		}
+		// gorm.Open returns the handle even when its ping fails; close its
+		// pool rather than leak one per attempt.
+		if conn != nil {
+			_ = Close(conn)
+		}
+
+		if attempt > cfg.Storage.ConnectRetries {
+			return nil, fmt.Errorf("db - NewGorm - giving up after %d attempts: %w", attempt, err)
+		}
+
+		l.Warn("db - NewGorm - attempt %d failed, retrying in %s: %s", attempt, backoff, err)
+		sleep(backoff)
+
+		backoff *= 2
+		if cfg.Storage.ConnectMaxBackoff > 0 && backoff > cfg.Storage.ConnectMaxBackoff {
+			backoff = cfg.Storage.ConnectMaxBackoff
+		}
+	}
+}
+
+func open(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+	switch cfg.Storage.Driver {
+	case "mysql":
+		return mysql.NewGormMysql(cfg, gc)
+	case "postgres":
+		return postgres.NewGormPostgres(cfg, gc)
+	case "sqlite":
+		return sqlite.NewGormSqlite(cfg, gc)
+	default:
+		return nil, fmt.Errorf("storage driver %q has no database", cfg.Storage.Driver)
+	}
+}
+
+func configurePool(conn *gorm.DB, s config.Storage) error {
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+
+	sqlDB.SetMaxOpenConns(s.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(s.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(s.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(s.ConnMaxIdleTime)
+
+	return sqlDB.Ping()
+}
+
+// RegisterMetrics exports the connection pool statistics of conn, labelled
+// with dbName, and the duration and errors of its statements on the default
+// Prometheus registry served at /metrics.
+func RegisterMetrics(conn *gorm.DB, dbName string) error {
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Use(instrument.Metrics{}); err != nil {
+		return err
+	}
+
+	return prometheus.Register(collectors.NewDBStatsCollector(sqlDB, dbName))
+}
+
+// RegisterTracing traces every statement run on conn.
+func RegisterTracing(conn *gorm.DB) error {
+	return conn.Use(instrument.Tracing{})
+}
+
+// Ping checks that conn can reach the database, for readiness probes.
+func Ping(conn *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := conn.DB()
+		if err != nil {
+			return err
+		}
+
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// Close closes the connection pool of conn.
+func Close(conn *gorm.DB) error {
+	sqlDB, err := conn.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
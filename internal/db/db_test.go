@@ -0,0 +1,141 @@
+package db
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"backend-test/config"
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/internal/db/instrument"
+	"backend-test/pkg/logger"
+)
+
+func sqliteConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.Storage.Driver = "sqlite"
+	cfg.SQLite.Path = filepath.Join(t.TempDir(), "test.db")
+	cfg.Storage.MaxOpenConns = 3
+	cfg.Storage.MaxIdleConns = 2
+	cfg.Storage.ConnectRetries = 4
+	cfg.Storage.ConnectBackoff = 100 * time.Millisecond
+	cfg.Storage.ConnectMaxBackoff = 300 * time.Millisecond
+	return cfg
+}
+
+func TestConnectBackoff(t *testing.T) {
+	cfg := sqliteConfig(t)
+	errPing := errors.New("connection refused")
+
+	// Like gorm.Open when the ping fails, the opener returns a live handle
+	// with its error.
+	var handles []*gorm.DB
+	failing := func(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+		conn, err := sqlite.Open(cfg.SQLite.Path)
+		if err != nil {
+			t.Fatalf("sqlite.Open: %v", err)
+		}
+		handles = append(handles, conn)
+		return conn, errPing
+	}
+	var sleeps []time.Duration
+	sleep := func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	_, err := connect(cfg, logger.New("error"), failing, sleep)
+	if !errors.Is(err, errPing) || !strings.Contains(err.Error(), "giving up after 5 attempts") {
+		t.Errorf("connect = %v, want it to give up after 5 attempts", err)
+	}
+	if len(handles) != 5 {
+		t.Errorf("attempts = %d, want 5", len(handles))
+	}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	if !reflect.DeepEqual(sleeps, want) {
+		t.Errorf("backoff = %v, want %v", sleeps, want)
+	}
+
+	for i, conn := range handles {
+		sqlDB, err := conn.DB()
+		if err != nil {
+			t.Fatalf("DB: %v", err)
+		}
+		if err := sqlDB.Ping(); err == nil {
+			t.Errorf("handle of attempt %d is still open", i+1)
+		}
+	}
+}
+
+func TestConnectRecovers(t *testing.T) {
+	cfg := sqliteConfig(t)
+
+	attempts := 0
+	flaky := func(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("connection refused")
+		}
+		return open(cfg, gc)
+	}
+
+	conn, err := connect(cfg, logger.New("error"), flaky, func(time.Duration) {})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(conn) })
+
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if _, ok := conn.Logger.(*instrument.Logger); !ok {
+		t.Errorf("logger = %T, want the instrumented logger from the first statement", conn.Logger)
+	}
+
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatalf("DB: %v", err)
+	}
+	if got := sqlDB.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("max open connections = %d, want 3", got)
+	}
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	conn, err := NewGorm(sqliteConfig(t), logger.New("error"))
+	if err != nil {
+		t.Fatalf("NewGorm: %v", err)
+	}
+	t.Cleanup(func() { _ = Close(conn) })
+
+	if err := RegisterMetrics(conn, "db_test"); err != nil {
+		t.Fatalf("RegisterMetrics: %v", err)
+	}
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != "go_sql_max_open_connections" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "db_name" && label.GetValue() == "db_test" {
+					if got := m.GetGauge().GetValue(); got != 3 {
+						t.Errorf("go_sql_max_open_connections = %v, want 3", got)
+					}
+					return
+				}
+			}
+		}
+	}
+	t.Errorf("no go_sql_max_open_connections series for db_test")
+}
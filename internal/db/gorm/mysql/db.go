@@ -2,14 +2,86 @@ package mysql
 
 import (
 	"backend-test/config"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
+	"os"
+	"time"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-func NewGormMysql(cfg *config.Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local", cfg.MYSQL.Username, cfg.MYSQL.Password, cfg.MYSQL.Host, cfg.MYSQL.Port, cfg.MYSQL.Dbname)
-	return gorm.Open(mysql.Open(dsn), &gorm.Config{})
+const _customTLSConfig = "custom"
 
+func NewGormMysql(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+	dsn, err := DSN(cfg.MYSQL)
+	if err != nil {
+		return nil, err
+	}
+
+	return gorm.Open(mysql.Open(dsn), gc)
+}
+
+// DSN builds the driver connection string, registering the custom TLS config if needed.
+func DSN(c config.MYSQL) (string, error) {
+	dc := mysqldriver.NewConfig()
+	dc.User = c.Username
+	dc.Passwd = c.Password
+	dc.Net = "tcp"
+	dc.Addr = net.JoinHostPort(c.Host, c.Port)
+	dc.DBName = c.Dbname
+	dc.Params = map[string]string{"charset": "utf8mb4"}
+	dc.ParseTime = true
+	dc.Loc = time.Local
+	dc.Timeout = c.DialTimeout
+	dc.ReadTimeout = c.ReadTimeout
+	dc.WriteTimeout = c.WriteTimeout
+
+	switch c.TLS {
+	case "", "false":
+	case _customTLSConfig:
+		tlsCfg, err := customTLS(c)
+		if err != nil {
+			return "", err
+		}
+		if err := mysqldriver.RegisterTLSConfig(_customTLSConfig, tlsCfg); err != nil {
+			return "", fmt.Errorf("mysql - DSN - RegisterTLSConfig: %w", err)
+		}
+		dc.TLSConfig = _customTLSConfig
+	default:
+		dc.TLSConfig = c.TLS
+	}
+
+	return dc.FormatDSN(), nil
+}
+
+func customTLS(c config.MYSQL) (*tls.Config, error) {
+	ca, err := os.ReadFile(c.TLSCA)
+	if err != nil {
+		return nil, fmt.Errorf("mysql - customTLS - read CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("mysql - customTLS - no certificates in %s", c.TLSCA)
+	}
+
+	tlsCfg := &tls.Config{
+		RootCAs:    pool,
+		ServerName: c.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.TLSCert != "" || c.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLSCert, c.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("mysql - customTLS - load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
 }
@@ -0,0 +1,47 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"backend-test/config"
+)
+
+func TestDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		tls      string
+		wantTLS  string
+	}{
+		{"Plain", "admin", "", ""},
+		{"Special", `p@ss/w:rd?'\`, "false", ""},
+		{"TLS", "admin", "skip-verify", "skip-verify"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dsn, err := DSN(config.MYSQL{
+				Host: "db.internal", Port: "3307", Username: "app", Password: tt.password,
+				Dbname: "business", TLS: tt.tls, DialTimeout: 3 * time.Second,
+			})
+			if err != nil {
+				t.Fatalf("DSN: %v", err)
+			}
+
+			cfg, err := mysqldriver.ParseDSN(dsn)
+			if err != nil {
+				t.Fatalf("ParseDSN(%s): %v", dsn, err)
+			}
+			if cfg.Passwd != tt.password || cfg.Addr != "db.internal:3307" || cfg.DBName != "business" {
+				t.Errorf("parsed %+v, want password %q at db.internal:3307/business", cfg, tt.password)
+			}
+			if cfg.TLSConfig != tt.wantTLS || cfg.Timeout != 3*time.Second || !cfg.ParseTime {
+				t.Errorf("parsed tls %q, timeout %s, parseTime %v", cfg.TLSConfig, cfg.Timeout, cfg.ParseTime)
+			}
+		})
+	}
+}
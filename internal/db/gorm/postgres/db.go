@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"backend-test/config"
+	"fmt"
+	"math"
+	"strings"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func NewGormPostgres(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(DSN(cfg.Postgres)), gc)
+}
+
+// DSN builds the libpq-style connection string. Every value is quoted, so
+// passwords and paths may contain spaces, quotes and backslashes or be empty.
+func DSN(c config.Postgres) string {
+	params := []string{
+		param("host", c.Host),
+		param("port", c.Port),
+		param("user", c.Username),
+		param("password", c.Password),
+		param("dbname", c.Dbname),
+		param("sslmode", c.SSLMode),
+	}
+
+	if c.ConnectTimeout > 0 {
+		// libpq takes whole seconds and treats 0 as "wait forever".
+		params = append(params, param("connect_timeout", fmt.Sprint(int(math.Ceil(c.ConnectTimeout.Seconds())))))
+	}
+	if c.SSLRootCert != "" {
+		params = append(params, param("sslrootcert", c.SSLRootCert))
+	}
+	if c.SSLCert != "" {
+		params = append(params, param("sslcert", c.SSLCert))
+	}
+	if c.SSLKey != "" {
+		params = append(params, param("sslkey", c.SSLKey))
+	}
+
+	return strings.Join(params, " ")
+}
+
+var _quoteEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// param formats key='value', escaping the value as libpq expects.
+func param(key, value string) string {
+	return key + "='" + _quoteEscaper.Replace(value) + "'"
+}
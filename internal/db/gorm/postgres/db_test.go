@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+
+	"backend-test/config"
+)
+
+func TestDSN(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		dbname   string
+	}{
+		{"Plain", "admin", "business"},
+		{"Empty", "", "business"},
+		{"Space", "two words", "my db"},
+		{"Quote", `it's`, "business"},
+		{"Backslash", `back\slash\`, "business"},
+		{"Mixed", ` '\' = `, "business"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dsn := DSN(config.Postgres{
+				Host: "db.internal", Port: "5433", Username: "app",
+				Password: tt.password, Dbname: tt.dbname, SSLMode: "disable",
+				ConnectTimeout: 1500 * time.Millisecond,
+			})
+
+			cfg, err := pgconn.ParseConfig(dsn)
+			if err != nil {
+				t.Fatalf("ParseConfig(%s): %v", dsn, err)
+			}
+			if cfg.Password != tt.password || cfg.Database != tt.dbname {
+				t.Errorf("parsed password, dbname = %q, %q, want %q, %q", cfg.Password, cfg.Database, tt.password, tt.dbname)
+			}
+			if cfg.Host != "db.internal" || cfg.Port != 5433 || cfg.User != "app" {
+				t.Errorf("parsed %s:%d as %s, want db.internal:5433 as app", cfg.Host, cfg.Port, cfg.User)
+			}
+			if cfg.ConnectTimeout != 2*time.Second {
+				t.Errorf("connect timeout = %s, want 2s rounded up", cfg.ConnectTimeout)
+			}
+		})
+	}
+}
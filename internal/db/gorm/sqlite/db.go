@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"backend-test/config"
+	"backend-test/internal/entity"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// DriverName is the database/sql driver registered by this package. It is the
+// stock sqlite3 driver plus the SQL functions the repositories rely on.
+const DriverName = "sqlite3_backend_test"
+
+var registerOnce sync.Once
+
+func register() {
+	sql.Register(DriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			// distance_meters(lat1, lon1, lat2, lon2) backs geo search.
+			return conn.RegisterFunc("distance_meters", entity.Haversine, true)
+		},
+	})
+}
+
+func NewGormSqlite(cfg *config.Config, gc *gorm.Config) (*gorm.DB, error) {
+	return open(cfg.SQLite.Path, gc)
+}
+
+// Open opens the SQLite database at path, creating its directory if needed.
+// Foreign keys are enforced on every connection.
+func Open(path string) (*gorm.DB, error) {
+	return open(path, &gorm.Config{})
+}
+
+func open(path string, gc *gorm.Config) (*gorm.DB, error) {
+	registerOnce.Do(register)
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("sqlite - Open - mkdir: %w", err)
+		}
+	}
+
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate", path)
+	return gorm.Open(&sqlite.Dialector{DriverName: DriverName, DSN: dsn}, gc)
+}
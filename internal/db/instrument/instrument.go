@@ -0,0 +1,116 @@
+// Package instrument observes the SQL statements GORM runs, labelled with the
+// repository operation that issued them.
+package instrument
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+type operationKey struct{}
+
+// WithOperation names the repository operation (create, read, search...) that
+// statements run with ctx belong to.
+func WithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// Operation returns the operation set by WithOperation, or "".
+func Operation(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+	return op
+}
+
+var (
+	_queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gorm_query_duration_seconds",
+		Help:    "Duration of SQL statements by repository operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation"})
+
+	_queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gorm_query_errors_total",
+		Help: "SQL statements that failed, by repository operation. Missing rows are not errors.",
+	}, []string{"operation"})
+)
+
+const _startKey = "instrument:start"
+
+// Metrics -.
+type Metrics struct{}
+
+// Name -.
+func (Metrics) Name() string {
+	return "instrument:metrics"
+}
+
+// Initialize registers callbacks around every kind of statement.
+func (Metrics) Initialize(db *gorm.DB) error {
+	return register(db, "metrics", func(db *gorm.DB) {
+		db.InstanceSet(_startKey, time.Now())
+	}, func(kind string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			v, ok := db.InstanceGet(_startKey)
+			if !ok {
+				return
+			}
+
+			op := operationOf(db, kind)
+			_queryDuration.WithLabelValues(op).Observe(time.Since(v.(time.Time)).Seconds())
+			if failed(db) {
+				_queryErrors.WithLabelValues(op).Inc()
+			}
+		}
+	})
+}
+
+// register adds before and after callbacks to each GORM processor. after is
+// called with the processor kind: create, query, update, delete, row or raw.
+func register(db *gorm.DB, name string, before func(*gorm.DB), after func(kind string) func(*gorm.DB)) error {
+	type registrar interface {
+		Register(name string, fn func(*gorm.DB)) error
+	}
+
+	cb := db.Callback()
+	processors := []struct {
+		kind          string
+		before, after registrar
+	}{
+		{"create", cb.Create().Before("*"), cb.Create().After("*")},
+		{"query", cb.Query().Before("*"), cb.Query().After("*")},
+		{"update", cb.Update().Before("*"), cb.Update().After("*")},
+		{"delete", cb.Delete().Before("*"), cb.Delete().After("*")},
+		{"row", cb.Row().Before("*"), cb.Row().After("*")},
+		{"raw", cb.Raw().Before("*"), cb.Raw().After("*")},
+	}
+
+	for _, p := range processors {
+		prefix := "instrument:" + name + ":" + p.kind
+		if err := p.before.Register(prefix+":before", before); err != nil {
+			return err
+		}
+		if err := p.after.Register(prefix+":after", after(p.kind)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func operationOf(db *gorm.DB, kind string) string {
+	if db.Statement.Context != nil {
+		if op := Operation(db.Statement.Context); op != "" {
+			return op
+		}
+	}
+	return kind
+}
+
+func failed(db *gorm.DB) bool {
+	return db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound)
+}
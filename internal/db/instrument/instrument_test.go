@@ -0,0 +1,56 @@
+package instrument
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(_queryDuration, _queryErrors)
+
+	db := newDB(t, newRecorder(), 0)
+	if err := db.Use(Metrics{}); err != nil {
+		t.Fatalf("Use: %v", err)
+	}
+
+	var secrets []secret
+	read := WithOperation(context.Background(), "metrics_test_read")
+	if err := db.WithContext(read).Find(&secrets).Error; err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	broken := WithOperation(context.Background(), "metrics_test_broken")
+	if err := db.WithContext(broken).Exec("SELECT * FROM no_such_table").Error; err == nil {
+		t.Fatalf("Exec on a missing table succeeded")
+	}
+
+	durations := map[string]uint64{}
+	errs := map[string]float64{}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			op := m.GetLabel()[0].GetValue()
+			switch f.GetName() {
+			case "gorm_query_duration_seconds":
+				durations[op] = m.GetHistogram().GetSampleCount()
+			case "gorm_query_errors_total":
+				errs[op] = m.GetCounter().GetValue()
+			}
+		}
+	}
+
+	if durations["metrics_test_read"] != 1 || durations["metrics_test_broken"] != 1 {
+		t.Errorf("duration observations = %v, want one per operation", durations)
+	}
+	if errs["metrics_test_broken"] != 1 {
+		t.Errorf("errors = %v, want one for metrics_test_broken", errs)
+	}
+	if _, ok := errs["metrics_test_read"]; ok {
+		t.Errorf("errors = %v, want none for the successful read", errs)
+	}
+}
@@ -0,0 +1,108 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"backend-test/pkg/logger"
+)
+
+// Logger writes GORM's log output to pkg/logger. Statements are logged at
+// debug level with their duration and rows affected, and statements slower
+// than the threshold at warn level. Bound parameters are never logged.
+type Logger struct {
+	l     logger.Interface
+	slow  time.Duration
+	level gormlogger.LogLevel
+}
+
+var (
+	_ gormlogger.Interface = (*Logger)(nil)
+	_ gorm.ParamsFilter    = (*Logger)(nil)
+)
+
+// NewLogger returns a GORM logger backed by l. A slow threshold of 0 disables
+// slow statement warnings.
+func NewLogger(l logger.Interface, slow time.Duration) *Logger {
+	return &Logger{
+		l:     l,
+		slow:  slow,
+		level: gormlogger.Info,
+	}
+}
+
+// LogMode -.
+func (gl *Logger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	c := *gl
+	c.level = level
+	return &c
+}
+
+// Info -.
+func (gl *Logger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Info {
+		gl.log(ctx).Info("db - "+msg, args...)
+	}
+}
+
+// Warn -.
+func (gl *Logger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Warn {
+		gl.log(ctx).Warn("db - "+msg, args...)
+	}
+}
+
+// Error -.
+func (gl *Logger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Error {
+		gl.log(ctx).Error("db - "+msg, args...)
+	}
+}
+
+// Trace logs a finished statement.
+func (gl *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if gl.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+
+	fields := logger.Fields{
+		"sql":         sql,
+		"rows":        rows,
+		"duration_ms": float64(elapsed.Microseconds()) / 1000,
+	}
+	if op := Operation(ctx); op != "" {
+		fields["operation"] = op
+	}
+	l := gl.log(ctx).With(fields)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && gl.level >= gormlogger.Error:
+		l.Error(fmt.Errorf("db - statement failed: %w", err))
+	case gl.slow > 0 && elapsed > gl.slow && gl.level >= gormlogger.Warn:
+		l.Warn("db - slow statement, over %s", gl.slow)
+	case gl.level >= gormlogger.Info:
+		l.Debug("db - statement")
+	}
+}
+
+// ParamsFilter drops the bound parameters, so statements are logged with
+// their placeholders instead of user data.
+func (gl *Logger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+// log returns the logger of the request in ctx, falling back to gl.l.
+func (gl *Logger) log(ctx context.Context) logger.Interface {
+	if ctx == nil {
+		return gl.l
+	}
+	return logger.FromContext(ctx, gl.l)
+}
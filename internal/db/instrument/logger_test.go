@@ -0,0 +1,147 @@
+package instrument
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend-test/internal/db/gorm/sqlite"
+	"backend-test/pkg/logger"
+)
+
+type entry struct {
+	level   string
+	message string
+	fields  logger.Fields
+}
+
+// recorder is a logger.Interface keeping every entry in memory.
+type recorder struct {
+	mu      *sync.Mutex
+	entries *[]entry
+	fields  logger.Fields
+}
+
+func newRecorder() recorder {
+	return recorder{mu: &sync.Mutex{}, entries: &[]entry{}}
+}
+
+func (r recorder) add(level string, message interface{}, args ...interface{}) {
+	msg := fmt.Sprint(message)
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.entries = append(*r.entries, entry{level: level, message: msg, fields: r.fields})
+}
+
+func (r recorder) Debug(message interface{}, args ...interface{}) { r.add("debug", message, args...) }
+func (r recorder) Info(message string, args ...interface{})       { r.add("info", message, args...) }
+func (r recorder) Warn(message string, args ...interface{})       { r.add("warn", message, args...) }
+func (r recorder) Error(message interface{}, args ...interface{}) { r.add("error", message, args...) }
+func (r recorder) Fatal(message interface{}, args ...interface{}) { r.add("fatal", message, args...) }
+
+func (r recorder) With(fields logger.Fields) logger.Interface {
+	merged := logger.Fields{}
+	for k, v := range r.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	r.fields = merged
+	return r
+}
+
+func (r recorder) reset() []entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := *r.entries
+	*r.entries = nil
+	return out
+}
+
+type secret struct {
+	ID    uint
+	Value string
+}
+
+func newDB(t *testing.T, l logger.Interface, slow time.Duration) *gorm.DB {
+	t.Helper()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	if err := db.AutoMigrate(&secret{}); err != nil {
+		t.Fatalf("AutoMigrate: %v", err)
+	}
+	return db.Session(&gorm.Session{Logger: NewLogger(l, slow)})
+}
+
+func TestLoggerOmitsParams(t *testing.T) {
+	r := newRecorder()
+	db := newDB(t, r, 0)
+
+	if err := db.Create(&secret{Value: "hunter2"}).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var s secret
+	if err := db.Where("value = ?", "hunter2").First(&s).Error; err != nil {
+		t.Fatalf("First: %v", err)
+	}
+
+	entries := r.reset()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want one per statement", entries)
+	}
+	for _, e := range entries {
+		sql, _ := e.fields["sql"].(string)
+		if e.level != "debug" || !strings.Contains(sql, "?") {
+			t.Errorf("entry = %+v, want a debug statement with placeholders", e)
+		}
+		if strings.Contains(fmt.Sprint(e), "hunter2") {
+			t.Errorf("entry = %+v, want the bound value left out", e)
+		}
+	}
+}
+
+func TestLoggerLevels(t *testing.T) {
+	r := newRecorder()
+	db := newDB(t, r, time.Nanosecond)
+
+	var s secret
+	err := db.First(&s, 42).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("First(missing) = %v, want ErrRecordNotFound", err)
+	}
+	entries := r.reset()
+	if len(entries) != 1 || entries[0].level != "warn" || !strings.Contains(entries[0].message, "slow statement") {
+		t.Errorf("missing row over the slow threshold = %+v, want a slow statement warning and no error", entries)
+	}
+
+	if err := db.Exec("SELECT * FROM no_such_table").Error; err == nil {
+		t.Fatalf("Exec on a missing table succeeded")
+	}
+	entries = r.reset()
+	if len(entries) != 1 || entries[0].level != "error" || !strings.Contains(entries[0].message, "no such table") {
+		t.Errorf("failing statement = %+v, want an error", entries)
+	}
+
+	db = newDB(t, r, time.Hour)
+	r.reset()
+	if err := db.First(&s, 42).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("First(missing) = %v, want ErrRecordNotFound", err)
+	}
+	if entries = r.reset(); len(entries) != 1 || entries[0].level != "debug" {
+		t.Errorf("fast missing row = %+v, want a debug statement", entries)
+	}
+}
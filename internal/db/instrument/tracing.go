@@ -0,0 +1,64 @@
+package instrument
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	_tracerName = "backend-test/internal/db"
+	_spanKey    = "instrument:span"
+)
+
+// Tracing starts a client span for every SQL statement, as a child of the span
+// in the statement's context. Bound parameters are not recorded.
+type Tracing struct{}
+
+// Name -.
+func (Tracing) Name() string {
+	return "instrument:tracing"
+}
+
+// Initialize registers callbacks around every kind of statement.
+func (Tracing) Initialize(db *gorm.DB) error {
+	tracer := otel.Tracer(_tracerName)
+	system := db.Dialector.Name()
+
+	return register(db, "tracing", func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+
+		_, span := tracer.Start(db.Statement.Context, "gorm", trace.WithSpanKind(trace.SpanKindClient))
+		db.InstanceSet(_spanKey, span)
+	}, func(kind string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			v, ok := db.InstanceGet(_spanKey)
+			if !ok {
+				return
+			}
+			span := v.(trace.Span)
+			defer span.End()
+
+			op := operationOf(db, kind)
+			span.SetName("gorm." + op)
+			span.SetAttributes(
+				semconv.DBSystemKey.String(system),
+				semconv.DBStatement(db.Statement.SQL.String()),
+				semconv.DBOperation(kind),
+				semconv.DBSQLTable(db.Statement.Table),
+				attribute.String("db.repo_operation", op),
+				attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+			)
+
+			if failed(db) {
+				span.RecordError(db.Error)
+				span.SetStatus(codes.Error, db.Error.Error())
+			}
+		}
+	})
+}
@@ -0,0 +1,352 @@
+// Package migrate applies versioned SQL schema migrations embedded in the binary.
+// Migrations live in migrations/<dialect>/ as NNNNNN_name.up.sql and
+// NNNNNN_name.down.sql pairs; applied versions are recorded in schema_migrations.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const _tableName = "schema_migrations"
+
+var (
+	// ErrSchemaOutdated is returned by Check when migrations are pending.
+	ErrSchemaOutdated = errors.New("database schema is outdated, run migrations first")
+	// ErrUnsupportedDialect is returned when no migrations exist for the database dialect.
+	ErrUnsupportedDialect = errors.New("no migrations for database dialect")
+)
+
+//go:embed migrations
+var _migrations embed.FS
+
+// Migration -.
+type Migration struct {
+	Version uint
+	Name    string
+	up      string
+	down    string
+}
+
+// Status -.
+type Status struct {
+	Version   uint       `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+type schemaMigration struct {
+	Version   uint
+	Name      string
+	AppliedAt time.Time
+}
+
+// Migrator -.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New loads the migrations matching the dialect of db.
+func New(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+
+	migrations, err := load(_migrations, path.Join("migrations", dialect))
+	if err != nil {
+		return nil, fmt.Errorf("migrate - New - load %s: %w", dialect, err)
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mg := range m.migrations {
+		if _, ok := applied[mg.Version]; ok {
+			continue
+		}
+
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := exec(tx, mg.up); err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO "+_tableName+" (version, name, applied_at) VALUES (?, ?, ?)",
+				mg.Version, mg.Name, time.Now().UTC()).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migrate - Up - %06d_%s: %w", mg.Version, mg.Name, err)
+		}
+
+		done = append(done, mg)
+	}
+
+	return done, nil
+}
+
+// Down reverts the last steps applied migrations and returns the ones reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mg := m.migrations[i]
+		if _, ok := applied[mg.Version]; !ok {
+			continue
+		}
+
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := exec(tx, mg.down); err != nil {
+				return err
+			}
+			return tx.Exec("DELETE FROM "+_tableName+" WHERE version = ?", mg.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migrate - Down - %06d_%s: %w", mg.Version, mg.Name, err)
+		}
+
+		done = append(done, mg)
+	}
+
+	return done, nil
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied := map[uint]schemaMigration{}
+	if m.db.WithContext(ctx).Migrator().HasTable(_tableName) {
+		var err error
+		if applied, err = m.applied(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		s := Status{Version: mg.Version, Name: mg.Name}
+		if sm, ok := applied[mg.Version]; ok {
+			appliedAt := sm.AppliedAt
+			s.Applied = true
+			s.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses, nil
+}
+
+// Check returns ErrSchemaOutdated if any migration has not been applied yet.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, s := range statuses {
+		if !s.Applied {
+			pending++
+		}
+	}
+
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending", ErrSchemaOutdated, pending)
+	}
+
+	return nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	err := m.db.WithContext(ctx).Exec("CREATE TABLE IF NOT EXISTS " + _tableName + " (" +
+		"version BIGINT NOT NULL PRIMARY KEY, " +
+		"name VARCHAR(255) NOT NULL, " +
+		"applied_at TIMESTAMP NOT NULL)").Error
+	if err != nil {
+		return fmt.Errorf("migrate - ensureTable: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[uint]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := m.db.WithContext(ctx).Table(_tableName).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("migrate - applied: %w", err)
+	}
+
+	applied := make(map[uint]schemaMigration, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r
+	}
+
+	return applied, nil
+}
+
+func exec(tx *gorm.DB, script string) error {
+	for _, stmt := range split(script) {
+		if err := tx.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// split breaks a script into statements at the semicolons outside string
+// literals and quoted identifiers. "--" comments are dropped. Backslash
+// escapes and PostgreSQL dollar quoting ($$ ... $$) are not understood, so
+// migrations write a quote inside a literal as two quotes and define no
+// function bodies.
+func split(script string) []string {
+	var (
+		stmts []string
+		cur   strings.Builder
+		quote rune // the quote of the open literal or identifier, if any
+	)
+
+	flush := func() {
+		if stmt := strings.TrimSpace(cur.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		cur.Reset()
+	}
+
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			// A doubled quote closes and reopens the literal, which keeps it open.
+			if r == quote {
+				quote = 0
+			}
+			cur.WriteRune(r)
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			cur.WriteRune(r)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			cur.WriteRune('\n')
+		case r == ';':
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return stmts
+}
+
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrUnsupportedDialect
+		}
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		version, name, direction, err := parseFilename(e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: name}
+			byVersion[version] = mg
+		} else if mg.Name != name {
+			return nil, fmt.Errorf("migration %06d has conflicting names %q and %q", version, mg.Name, name)
+		}
+
+		if direction == "up" {
+			mg.up = string(body)
+		} else {
+			mg.down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		if mg.up == "" || mg.down == "" {
+			return nil, fmt.Errorf("migration %06d_%s must have both up and down files", mg.Version, mg.Name)
+		}
+		migrations = append(migrations, *mg)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parseFilename splits "000001_create_businesses.up.sql" into its parts.
+func parseFilename(filename string) (uint, string, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	if base == filename {
+		return 0, "", "", fmt.Errorf("migration %q: not a .sql file", filename)
+	}
+
+	ext := path.Ext(base)
+	direction := strings.TrimPrefix(ext, ".")
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("migration %q: expected .up.sql or .down.sql", filename)
+	}
+
+	versionStr, name, ok := strings.Cut(strings.TrimSuffix(base, ext), "_")
+	if !ok {
+		return 0, "", "", fmt.Errorf("migration %q: expected NNNNNN_name prefix", filename)
+	}
+
+	version, err := strconv.ParseUint(versionStr, 10, 64)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("migration %q: invalid version: %w", filename, err)
+	}
+
+	return uint(version), name, direction, nil
+}
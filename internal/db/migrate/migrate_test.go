@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"backend-test/internal/db/gorm/sqlite"
+)
+
+func newSqliteMigrator(t *testing.T) *Migrator {
+	t.Helper()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sqlite.Open: %v", err)
+	}
+	m, err := New(db)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return m
+}
+
+func TestUpStatusDown(t *testing.T) {
+	m := newSqliteMigrator(t)
+	ctx := context.Background()
+
+	if err := m.Check(ctx); !errors.Is(err, ErrSchemaOutdated) {
+		t.Fatalf("Check on an empty database = %v, want ErrSchemaOutdated", err)
+	}
+
+	applied, err := m.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(m.migrations) {
+		t.Fatalf("Up applied %d migrations, want %d", len(applied), len(m.migrations))
+	}
+	if again, err := m.Up(ctx); err != nil || len(again) != 0 {
+		t.Errorf("second Up = %d, %v, want nothing to do", len(again), err)
+	}
+	if err := m.Check(ctx); err != nil {
+		t.Errorf("Check after Up: %v", err)
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.AppliedAt == nil {
+			t.Errorf("status of %06d_%s = %+v, want applied", s.Version, s.Name, s)
+		}
+	}
+
+	reverted, err := m.Down(ctx, 1)
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	last := m.migrations[len(m.migrations)-1]
+	if len(reverted) != 1 || reverted[0].Version != last.Version {
+		t.Fatalf("Down(1) reverted %+v, want %06d", reverted, last.Version)
+	}
+
+	statuses, err = m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if s := statuses[len(statuses)-1]; s.Applied {
+		t.Errorf("status of the reverted migration = %+v, want pending", s)
+	}
+	if err := m.Check(ctx); !errors.Is(err, ErrSchemaOutdated) {
+		t.Errorf("Check with one pending migration = %v, want ErrSchemaOutdated", err)
+	}
+
+	if _, err := m.Down(ctx, len(m.migrations)); err != nil {
+		t.Fatalf("Down all: %v", err)
+	}
+	if m.db.Migrator().HasTable("businesses") {
+		t.Errorf("businesses table still exists after reverting every migration")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{
+			name:   "Statements",
+			script: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n",
+			want:   []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"},
+		},
+		{
+			name:   "Comments",
+			script: "-- first; not a statement\nSELECT 1; -- trailing;\n",
+			want:   []string{"SELECT 1"},
+		},
+		{
+			name:   "SemicolonInLiteral",
+			script: "INSERT INTO t (v) VALUES ('a;\nb;');\nINSERT INTO t (v) VALUES ('it''s; fine');",
+			want: []string{
+				"INSERT INTO t (v) VALUES ('a;\nb;')",
+				"INSERT INTO t (v) VALUES ('it''s; fine')",
+			},
+		},
+		{
+			name:   "SemicolonInIdentifier",
+			script: `SELECT "a;b" FROM t; SELECT 2`,
+			want:   []string{`SELECT "a;b" FROM t`, "SELECT 2"},
+		},
+		{
+			name:   "DashesInLiteral",
+			script: "INSERT INTO t (v) VALUES ('--;');",
+			want:   []string{"INSERT INTO t (v) VALUES ('--;')"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := split(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("split = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
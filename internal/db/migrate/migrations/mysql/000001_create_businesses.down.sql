@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS business_categories;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS businesses;
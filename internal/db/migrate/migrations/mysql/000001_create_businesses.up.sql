@@ -0,0 +1,51 @@
+-- Baseline schema. Matches the tables previously created by AutoMigrate so
+-- existing databases can adopt versioned migrations without data loss.
+CREATE TABLE IF NOT EXISTS businesses (
+    uuid              BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    alias             VARCHAR(191),
+    cord_latitude     DOUBLE,
+    cord_longitude    DOUBLE,
+    display_phone     LONGTEXT,
+    id                VARCHAR(191) NOT NULL,
+    image_url         LONGTEXT,
+    open_time         TIME,
+    close_time        TIME,
+    loc_address1      LONGTEXT,
+    loc_address2      LONGTEXT,
+    loc_address3      LONGTEXT,
+    loc_city          LONGTEXT,
+    loc_country       LONGTEXT,
+    loc_display_address JSON,
+    loc_state         LONGTEXT,
+    loc_zip_code      LONGTEXT,
+    name              LONGTEXT,
+    phone             LONGTEXT,
+    price             LONGTEXT,
+    rating            BIGINT,
+    review_count      BIGINT,
+    transactions      JSON,
+    attributes        JSON,
+    url               LONGTEXT,
+    created_at        DATETIME(3),
+    updated_at        DATETIME(3),
+    deleted_at        DATETIME(3),
+    PRIMARY KEY (uuid),
+    UNIQUE INDEX idx_businesses_alias (alias),
+    UNIQUE INDEX idx_businesses_id (id),
+    INDEX idx_businesses_deleted_at (deleted_at)
+);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id    BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    alias LONGTEXT,
+    name  LONGTEXT,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS business_categories (
+    business_uuid BIGINT UNSIGNED NOT NULL,
+    categories_id BIGINT UNSIGNED NOT NULL,
+    PRIMARY KEY (business_uuid, categories_id),
+    CONSTRAINT fk_business_categories_business FOREIGN KEY (business_uuid) REFERENCES businesses (uuid),
+    CONSTRAINT fk_business_categories_categories FOREIGN KEY (categories_id) REFERENCES categories (id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX idx_categories_alias ON categories;
+ALTER TABLE categories MODIFY alias LONGTEXT;
@@ -0,0 +1,3 @@
+-- Search filters categories by alias, which LONGTEXT cannot index.
+ALTER TABLE categories MODIFY alias VARCHAR(191) NOT NULL;
+CREATE UNIQUE INDEX idx_categories_alias ON categories (alias);
@@ -0,0 +1,3 @@
+DELETE FROM categories
+WHERE alias IN ('fnb', 'office', 'factory')
+  AND id NOT IN (SELECT categories_id FROM (SELECT categories_id FROM business_categories) AS used);
@@ -0,0 +1,3 @@
+INSERT INTO categories (alias, name) SELECT 'fnb', 'fnb' FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM categories WHERE alias = 'fnb');
+INSERT INTO categories (alias, name) SELECT 'office', 'office' FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM categories WHERE alias = 'office');
+INSERT INTO categories (alias, name) SELECT 'factory', 'factory' FROM DUAL WHERE NOT EXISTS (SELECT 1 FROM categories WHERE alias = 'factory');
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS audit_log (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    action     VARCHAR(32) NOT NULL,
+    entity     VARCHAR(64) NOT NULL,
+    entity_id  VARCHAR(191) NOT NULL,
+    actor      VARCHAR(191) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_audit_log_entity (entity, entity_id)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name       VARCHAR(191) NOT NULL,
+    prefix     VARCHAR(16) NOT NULL,
+    hash       CHAR(64) NOT NULL,
+    scopes     VARCHAR(191) NOT NULL,
+    created_at DATETIME(3) NULL,
+    revoked_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_api_keys_hash (hash)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE businesses
+    DROP INDEX idx_businesses_owner_id,
+    DROP COLUMN owner_id;
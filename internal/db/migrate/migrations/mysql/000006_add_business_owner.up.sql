@@ -0,0 +1,3 @@
+ALTER TABLE businesses
+    ADD COLUMN owner_id VARCHAR(191) NOT NULL DEFAULT '',
+    ADD INDEX idx_businesses_owner_id (owner_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS business_claims (
+    id          BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    business_id VARCHAR(191) NOT NULL,
+    claimant    VARCHAR(191) NOT NULL,
+    message     TEXT NOT NULL,
+    status      VARCHAR(16) NOT NULL,
+    reviewed_by VARCHAR(191) NOT NULL DEFAULT '',
+    reviewed_at DATETIME(3) NULL,
+    created_at  DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_business_claims_status (status),
+    INDEX idx_business_claims_business (business_id, claimant)
+);
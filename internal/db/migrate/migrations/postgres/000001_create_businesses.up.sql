@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS businesses (
+    uuid                BIGSERIAL PRIMARY KEY,
+    alias               VARCHAR(191),
+    cord_latitude       DOUBLE PRECISION,
+    cord_longitude      DOUBLE PRECISION,
+    display_phone       TEXT,
+    id                  VARCHAR(191) NOT NULL,
+    image_url           TEXT,
+    open_time           TIME,
+    close_time          TIME,
+    loc_address1        TEXT,
+    loc_address2        TEXT,
+    loc_address3        TEXT,
+    loc_city            TEXT,
+    loc_country         TEXT,
+    loc_display_address JSONB,
+    loc_state           TEXT,
+    loc_zip_code        TEXT,
+    name                TEXT,
+    phone               TEXT,
+    price               TEXT,
+    rating              BIGINT,
+    review_count        BIGINT,
+    transactions        JSONB,
+    attributes          JSONB,
+    url                 TEXT,
+    created_at          TIMESTAMPTZ,
+    updated_at          TIMESTAMPTZ,
+    deleted_at          TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_businesses_alias ON businesses (alias);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_businesses_id ON businesses (id);
+CREATE INDEX IF NOT EXISTS idx_businesses_deleted_at ON businesses (deleted_at);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id    BIGSERIAL PRIMARY KEY,
+    alias TEXT,
+    name  TEXT
+);
+
+CREATE TABLE IF NOT EXISTS business_categories (
+    business_uuid BIGINT NOT NULL,
+    categories_id BIGINT NOT NULL,
+    PRIMARY KEY (business_uuid, categories_id),
+    CONSTRAINT fk_business_categories_business FOREIGN KEY (business_uuid) REFERENCES businesses (uuid),
+    CONSTRAINT fk_business_categories_categories FOREIGN KEY (categories_id) REFERENCES categories (id)
+);
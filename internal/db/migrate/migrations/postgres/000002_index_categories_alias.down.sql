@@ -0,0 +1,2 @@
+DROP INDEX idx_categories_alias;
+ALTER TABLE categories ALTER COLUMN alias DROP NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE categories ALTER COLUMN alias SET NOT NULL;
+CREATE UNIQUE INDEX idx_categories_alias ON categories (alias);
@@ -0,0 +1,3 @@
+INSERT INTO categories (alias, name) VALUES ('fnb', 'fnb') ON CONFLICT (alias) DO NOTHING;
+INSERT INTO categories (alias, name) VALUES ('office', 'office') ON CONFLICT (alias) DO NOTHING;
+INSERT INTO categories (alias, name) VALUES ('factory', 'factory') ON CONFLICT (alias) DO NOTHING;
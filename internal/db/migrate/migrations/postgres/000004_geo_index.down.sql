@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_businesses_cord_geog;
@@ -0,0 +1,4 @@
+-- Geo search uses ST_DWithin on the business coordinates.
+CREATE EXTENSION IF NOT EXISTS postgis;
+CREATE INDEX idx_businesses_cord_geog ON businesses
+    USING GIST ((ST_SetSRID(ST_MakePoint(cord_longitude, cord_latitude), 4326)::geography));
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS businesses (
+    uuid                INTEGER PRIMARY KEY AUTOINCREMENT,
+    alias               TEXT,
+    cord_latitude       REAL,
+    cord_longitude      REAL,
+    display_phone       TEXT,
+    id                  TEXT NOT NULL,
+    image_url           TEXT,
+    open_time           TEXT,
+    close_time          TEXT,
+    loc_address1        TEXT,
+    loc_address2        TEXT,
+    loc_address3        TEXT,
+    loc_city            TEXT,
+    loc_country         TEXT,
+    loc_display_address JSON,
+    loc_state           TEXT,
+    loc_zip_code        TEXT,
+    name                TEXT,
+    phone               TEXT,
+    price               TEXT,
+    rating              INTEGER,
+    review_count        INTEGER,
+    transactions        JSON,
+    attributes          JSON,
+    url                 TEXT,
+    created_at          DATETIME,
+    updated_at          DATETIME,
+    deleted_at          DATETIME
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_businesses_alias ON businesses (alias);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_businesses_id ON businesses (id);
+CREATE INDEX IF NOT EXISTS idx_businesses_deleted_at ON businesses (deleted_at);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id    INTEGER PRIMARY KEY AUTOINCREMENT,
+    alias TEXT,
+    name  TEXT
+);
+
+CREATE TABLE IF NOT EXISTS business_categories (
+    business_uuid INTEGER NOT NULL REFERENCES businesses (uuid),
+    categories_id INTEGER NOT NULL REFERENCES categories (id),
+    PRIMARY KEY (business_uuid, categories_id)
+);
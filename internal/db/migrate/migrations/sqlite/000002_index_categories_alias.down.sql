@@ -0,0 +1 @@
+DROP INDEX idx_categories_alias;
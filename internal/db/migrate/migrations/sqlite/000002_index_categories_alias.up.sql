@@ -0,0 +1 @@
+CREATE UNIQUE INDEX idx_categories_alias ON categories (alias);
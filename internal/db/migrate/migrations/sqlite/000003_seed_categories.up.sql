@@ -0,0 +1,3 @@
+INSERT OR IGNORE INTO categories (alias, name) VALUES ('fnb', 'fnb');
+INSERT OR IGNORE INTO categories (alias, name) VALUES ('office', 'office');
+INSERT OR IGNORE INTO categories (alias, name) VALUES ('factory', 'factory');
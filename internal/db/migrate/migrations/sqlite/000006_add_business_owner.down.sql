@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_businesses_owner_id;
+ALTER TABLE businesses DROP COLUMN owner_id;
@@ -0,0 +1,2 @@
+ALTER TABLE businesses ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_businesses_owner_id ON businesses (owner_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS business_claims (
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    business_id TEXT NOT NULL,
+    claimant    TEXT NOT NULL,
+    message     TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    reviewed_by TEXT NOT NULL DEFAULT '',
+    reviewed_at DATETIME,
+    created_at  DATETIME
+);
+CREATE INDEX IF NOT EXISTS idx_business_claims_status ON business_claims (status);
+CREATE INDEX IF NOT EXISTS idx_business_claims_business ON business_claims (business_id, claimant);
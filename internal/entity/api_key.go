@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// API key scopes. Each scope includes the ones below it: admin can write and
+// write can read.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+var _scopeRank = map[string]int{
+	ScopeRead:  1,
+	ScopeWrite: 2,
+	ScopeAdmin: 3,
+}
+
+// ValidScope reports whether s is a known scope.
+func ValidScope(s string) bool {
+	_, ok := _scopeRank[s]
+	return ok
+}
+
+// APIKey is a machine credential. Only the SHA-256 hash of the key is stored;
+// the prefix identifies it in listings.
+type APIKey struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"-"`
+	Scopes    string     `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// TableName -.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// ScopeList returns the space-separated scopes of k.
+func (k APIKey) ScopeList() []string {
+	return strings.Fields(k.Scopes)
+}
+
+// Revoked -.
+func (k APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Audit actions -.
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+
+	AuditClaimSubmit  = "claim_submit"
+	AuditClaimApprove = "claim_approve"
+	AuditClaimReject  = "claim_reject"
+)
+
+// AuditEntry records a single write made through the usecase layer.
+type AuditEntry struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Action    string    `json:"action"`
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entity_id"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName -.
+func (AuditEntry) TableName() string {
+	return "audit_log"
+}
@@ -3,6 +3,7 @@
 package entity
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -31,15 +32,16 @@ type Business struct {
 	Transactions datatypes.JSONType[[]string] `json:"transactions"`
 	Attributes   datatypes.JSONType[[]string] `json:"attributes"`
 	URL          string                       `json:"url"`
+	OwnerID      string                       `json:"-"`
 	CreatedAt    time.Time                    `json:"-"`
 	UpdatedAt    time.Time                    `json:"-"`
 	DeletedAt    gorm.DeletedAt               `json:"-" gorm:"index"`
 }
 
+// BusinessCategories is the join table behind Business.Categories.
 type BusinessCategories struct {
-	gorm.Model
-	BusinessID   uint
-	CategoriesID uint
+	BusinessUUID uint `gorm:"primaryKey"`
+	CategoriesID uint `gorm:"primaryKey"`
 }
 
 type Categories struct {
@@ -53,6 +55,40 @@ type Cordinates struct {
 	Longitude float64 `json:"longitude"`
 }
 
+// EarthRadius is the mean earth radius in meters used for distances.
+const EarthRadius = 6371008.8
+
+// DistanceTo returns the great-circle distance to o in meters.
+func (c Cordinates) DistanceTo(o Cordinates) float64 {
+	return Haversine(c.Latitude, c.Longitude, o.Latitude, o.Longitude)
+}
+
+// Haversine returns the great-circle distance in meters between two points
+// given in degrees.
+func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+
+	dLat := (lat2 - lat1) * rad
+	dLon := (lon2 - lon1) * rad
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// GeoFilter restricts a search to businesses within Radius meters of Center.
+// A zero Radius disables the filter.
+type GeoFilter struct {
+	Center Cordinates
+	Radius float64
+}
+
+// Enabled -.
+func (g GeoFilter) Enabled() bool {
+	return g.Radius > 0
+}
+
 type Location struct {
 	Address1       string                     `json:"address1"`
 	Address2       string                     `json:"address2"`
@@ -75,9 +111,12 @@ type SearchBusinessQueryParam struct {
 	CategoriesStr string  `form:"categories"`
 	AttributesStr string  `form:"attributes"`
 	Radius        float64 `form:"radius"`
+	Latitude      float64 `form:"latitude"`
+	Longitude     float64 `form:"longitude"`
 	Price         uint    `form:"price"`
 	OpenAt        uint    `form:"open_at"`
 	OpenNow       bool    `form:"open_now"`
+	Fields        string  `form:"fields"`
 }
 
 func (q *SearchBusinessQueryParam) Categories() []string {
@@ -100,6 +139,8 @@ type SearchBusinessParam struct {
 	Categories []string
 	Attributes []string
 	Radius     float64
+	Latitude   float64
+	Longitude  float64
 	Price      uint
 	OpenAt     uint
 	OpenNow    bool
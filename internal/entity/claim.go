@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Claim statuses -.
+const (
+	ClaimPending  = "pending"
+	ClaimApproved = "approved"
+	ClaimRejected = "rejected"
+)
+
+// BusinessClaim is a request by Claimant to become the owner of a business.
+// An admin approves it, which makes Claimant the business's owner, or
+// rejects it.
+type BusinessClaim struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	BusinessID string     `json:"business_id"`
+	Claimant   string     `json:"claimant"`
+	Message    string     `json:"message"`
+	Status     string     `json:"status"`
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName -.
+func (BusinessClaim) TableName() string {
+	return "business_claims"
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "errors"
+
+var (
+	// ErrNotFound is returned by repositories when no live record matches.
+	ErrNotFound = errors.New("record not found")
+	// ErrAlreadyExists is returned by repositories when a unique key is taken.
+	ErrAlreadyExists = errors.New("record already exists")
+	// ErrCanceled is returned when the caller gave up before the operation finished.
+	ErrCanceled = errors.New("operation canceled")
+	// ErrTimeout is returned when the operation ran past its deadline.
+	ErrTimeout = errors.New("operation timed out")
+	// ErrUnauthenticated is returned when credentials are missing or invalid.
+	ErrUnauthenticated = errors.New("authentication required")
+	// ErrForbidden is returned when the caller lacks the permission needed.
+	ErrForbidden = errors.New("permission denied")
+	// ErrRateLimited is returned when the caller used up its request quota.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrClaimReviewed is returned by repositories when reviewing a claim that
+	// is no longer pending.
+	ErrClaimReviewed = errors.New("claim has already been reviewed")
+)
@@ -0,0 +1,36 @@
+package entity
+
+import "context"
+
+// Fieldset is a sparse fieldset: the JSON names of the top-level fields a
+// client asked for, such as "id" or "categories". A nil Fieldset asks for
+// every field.
+type Fieldset []string
+
+// Has reports whether the field named name was asked for.
+func (f Fieldset) Has(name string) bool {
+	if f == nil {
+		return true
+	}
+	for _, field := range f {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+type fieldsetKey struct{}
+
+// WithFieldset returns a copy of ctx carrying f, so repositories can skip
+// loading what will not be serialized.
+func WithFieldset(ctx context.Context, f Fieldset) context.Context {
+	return context.WithValue(ctx, fieldsetKey{}, f)
+}
+
+// FieldsetFrom returns the fieldset stored by WithFieldset, or nil for every
+// field.
+func FieldsetFrom(ctx context.Context) Fieldset {
+	f, _ := ctx.Value(fieldsetKey{}).(Fieldset)
+	return f
+}
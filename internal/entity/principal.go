@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+)
+
+// Roles of dashboard users, taken from their token.
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the caller, e.g. "apikey:3" or "user:42".
+	Subject string
+	Scopes  []string
+	Roles   []string
+}
+
+// NewUserPrincipal is the principal of a dashboard user. Its scopes follow
+// its roles: admins get admin, editors write and everyone else read, so
+// routes can be guarded the same way as for API keys.
+func NewUserPrincipal(subject string, roles []string) Principal {
+	scope := ScopeRead
+	for _, r := range roles {
+		switch {
+		case r == RoleAdmin:
+			scope = ScopeAdmin
+		case r == RoleEditor && scope == ScopeRead:
+			scope = ScopeWrite
+		}
+	}
+
+	return Principal{
+		Subject: "user:" + subject,
+		Scopes:  []string{scope},
+		Roles:   roles,
+	}
+}
+
+// HasRole reports whether p has role. API keys act in the role matching their
+// scope: admin keys as admins and write keys as editors.
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	switch role {
+	case RoleAdmin:
+		return p.HasScope(ScopeAdmin)
+	case RoleEditor:
+		return p.HasScope(ScopeWrite)
+	default:
+		return false
+	}
+}
+
+// HasScope reports whether p was granted scope or a scope that includes it.
+func (p Principal) HasScope(scope string) bool {
+	want := _scopeRank[scope]
+	for _, s := range p.Scopes {
+		if have, ok := _scopeRank[s]; ok && have >= want {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeySubject is the subject of principals authenticated by an API key.
+func APIKeySubject(id uint) string {
+	return fmt.Sprintf("apikey:%d", id)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal stored by WithPrincipal, if any.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,21 @@
+// Package cache defines the byte-oriented backend interface of the response
+// caches and an in-process LRU implementing it.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Backend stores values under string keys. Implementations must be safe for
+// concurrent use; a shared one, such as Redis, lets several instances see
+// each other's invalidations.
+type Backend interface {
+	// Get returns the value of key and whether it was found and live.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl; a zero ttl keeps it until it is
+	// evicted or deleted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes keys; missing keys are not an error.
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUEviction(t *testing.T) {
+	c := NewLRU(2)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", []byte("1"), 0)
+	_ = c.Set(ctx, "b", []byte("2"), 0)
+	// Reading a makes b the least recently used.
+	if v, ok, _ := c.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Fatalf("Get(a) = %q, %v, want 1", v, ok)
+	}
+	_ = c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Errorf("b survived eviction")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len = %d, want 2", c.Len())
+	}
+}
+
+func TestLRUTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "short", []byte("x"), time.Second)
+	_ = c.Set(ctx, "forever", []byte("y"), 0)
+
+	now = now.Add(time.Second)
+	if _, ok, _ := c.Get(ctx, "short"); ok {
+		t.Errorf("short outlived its ttl")
+	}
+	if _, ok, _ := c.Get(ctx, "forever"); !ok {
+		t.Errorf("forever expired")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len = %d, want the expired entry dropped", c.Len())
+	}
+}
+
+func TestLRUOverwriteAndDelete(t *testing.T) {
+	c := NewLRU(10)
+	ctx := context.Background()
+
+	_ = c.Set(ctx, "a", []byte("1"), 0)
+	_ = c.Set(ctx, "a", []byte("2"), 0)
+	if v, _, _ := c.Get(ctx, "a"); string(v) != "2" {
+		t.Errorf("Get(a) = %q, want the new value", v)
+	}
+
+	if err := c.Delete(ctx, "a", "missing"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Errorf("a survived Delete")
+	}
+}
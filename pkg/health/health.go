@@ -0,0 +1,146 @@
+// Package health implements liveness and readiness checks.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	_defaultTimeout = 2 * time.Second
+
+	// StatusOK -.
+	StatusOK = "ok"
+	// StatusFailing -.
+	StatusFailing = "failing"
+	// StatusShuttingDown -.
+	StatusShuttingDown = "shutting_down"
+)
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+// Component is the outcome of one Check.
+type Component struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the readiness of the application and each registered dependency.
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components"`
+}
+
+// Ready -.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+type named struct {
+	name  string
+	check Check
+}
+
+// Health -.
+type Health struct {
+	mu           sync.RWMutex
+	checks       []named
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// New -.
+func New(opts ...Option) *Health {
+	h := &Health{
+		timeout: _defaultTimeout,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Register adds a dependency checked by Readiness.
+func (h *Health) Register(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, named{name: name, check: check})
+}
+
+// Shutdown makes Readiness fail from now on, so load balancers stop routing
+// new requests while in-flight ones finish.
+func (h *Health) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// Readiness runs every registered check concurrently, each bounded by the
+// configured timeout.
+func (h *Health) Readiness(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := append([]named(nil), h.checks...)
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	report := Report{Status: StatusOK, Components: make(map[string]Component, len(checks))}
+
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c named) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := run(ctx, c.check)
+			component := Component{Status: StatusOK, Duration: time.Since(start).String()}
+			if err != nil {
+				component.Status = StatusFailing
+				component.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[c.name] = component
+			if err != nil {
+				report.Status = StatusFailing
+			}
+		}(c)
+	}
+
+	wg.Wait()
+
+	if h.shuttingDown.Load() {
+		report.Status = StatusShuttingDown
+	}
+
+	return report
+}
+
+// run returns when check does or when ctx is done, whichever comes first, so
+// a check that ignores its context cannot hold the probe past the timeout.
+func run(ctx context.Context, check Check) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
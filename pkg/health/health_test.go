@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadinessComponents(t *testing.T) {
+	h := New()
+	h.Register("db", func(context.Context) error { return nil })
+	h.Register("cache", func(context.Context) error { return errors.New("connection refused") })
+
+	report := h.Readiness(context.Background())
+	if report.Ready() || report.Status != StatusFailing {
+		t.Errorf("status = %q, want %q", report.Status, StatusFailing)
+	}
+	if len(report.Components) != 2 {
+		t.Fatalf("components = %+v, want db and cache", report.Components)
+	}
+	if c := report.Components["db"]; c.Status != StatusOK || c.Error != "" || c.Duration == "" {
+		t.Errorf("db = %+v, want ok with a duration", c)
+	}
+	if c := report.Components["cache"]; c.Status != StatusFailing || c.Error != "connection refused" {
+		t.Errorf("cache = %+v, want failing with the error", c)
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded struct {
+		Status     string                       `json:"status"`
+		Components map[string]map[string]string `json:"components"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if decoded.Status != StatusFailing || decoded.Components["cache"]["error"] != "connection refused" {
+		t.Errorf("JSON = %s, want the failing cache component", body)
+	}
+	if _, ok := decoded.Components["db"]["error"]; ok {
+		t.Errorf("JSON = %s, want no error on the db component", body)
+	}
+
+	h = New()
+	h.Register("db", func(context.Context) error { return nil })
+	if report := h.Readiness(context.Background()); !report.Ready() {
+		t.Errorf("status with every check passing = %q, want %q", report.Status, StatusOK)
+	}
+}
+
+func TestReadinessTimeout(t *testing.T) {
+	h := New(Timeout(20 * time.Millisecond))
+	release := make(chan struct{})
+	defer close(release)
+	h.Register("slow", func(context.Context) error {
+		// Ignores its context, so only the timeout in run can end the probe.
+		<-release
+		return nil
+	})
+	h.Register("fast", func(context.Context) error { return nil })
+
+	start := time.Now()
+	report := h.Readiness(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Readiness took %v, want it bounded by the timeout", elapsed)
+	}
+	if report.Ready() {
+		t.Errorf("status = %q, want %q", report.Status, StatusFailing)
+	}
+	if c := report.Components["slow"]; c.Status != StatusFailing || c.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("slow = %+v, want failing with the deadline", c)
+	}
+	if c := report.Components["fast"]; c.Status != StatusOK {
+		t.Errorf("fast = %+v, want ok", c)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	h := New()
+	h.Register("db", func(context.Context) error { return nil })
+
+	if report := h.Readiness(context.Background()); !report.Ready() {
+		t.Fatalf("status before Shutdown = %q, want %q", report.Status, StatusOK)
+	}
+
+	h.Shutdown()
+	report := h.Readiness(context.Background())
+	if report.Ready() || report.Status != StatusShuttingDown {
+		t.Errorf("status after Shutdown = %q, want %q", report.Status, StatusShuttingDown)
+	}
+	if c := report.Components["db"]; c.Status != StatusOK {
+		t.Errorf("db after Shutdown = %+v, want it still checked and ok", c)
+	}
+}
@@ -0,0 +1,13 @@
+package health
+
+import "time"
+
+// Option -.
+type Option func(*Health)
+
+// Timeout bounds each readiness run.
+func Timeout(timeout time.Duration) Option {
+	return func(h *Health) {
+		h.timeout = timeout
+	}
+}
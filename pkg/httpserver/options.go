@@ -35,3 +35,47 @@ func ShutdownTimeout(timeout time.Duration) Option {
 		s.shutdownTimeout = timeout
 	}
 }
+
+// IdleTimeout -.
+func IdleTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.IdleTimeout = timeout
+	}
+}
+
+// ReadHeaderTimeout -.
+func ReadHeaderTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.server.ReadHeaderTimeout = timeout
+	}
+}
+
+// MaxHeaderBytes -.
+func MaxHeaderBytes(n int) Option {
+	return func(s *Server) {
+		s.server.MaxHeaderBytes = n
+	}
+}
+
+// TLS serves HTTPS with the given PEM certificate and key files.
+func TLS(certFile, keyFile string) Option {
+	return func(s *Server) {
+		s.certFile = certFile
+		s.keyFile = keyFile
+	}
+}
+
+// HTTP2 toggles HTTP/2 negotiated via ALPN. It only applies with TLS.
+func HTTP2(enabled bool) Option {
+	return func(s *Server) {
+		s.http2 = enabled
+	}
+}
+
+// H2C serves cleartext HTTP/2 (h2c) next to HTTP/1.1 when TLS is off. Only
+// enable it behind a proxy that speaks h2c to the server.
+func H2C(enabled bool) Option {
+	return func(s *Server) {
+		s.h2c = enabled
+	}
+}
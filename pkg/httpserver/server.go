@@ -3,8 +3,12 @@ package httpserver
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 const (
@@ -12,6 +16,7 @@ const (
 	_defaultWriteTimeout    = 5 * time.Second
 	_defaultAddr            = ":80"
 	_defaultShutdownTimeout = 3 * time.Second
+	_defaultIdleTimeout     = 120 * time.Second
 )
 
 // Server -.
@@ -19,6 +24,10 @@ type Server struct {
 	server          *http.Server
 	notify          chan error
 	shutdownTimeout time.Duration
+	certFile        string
+	keyFile         string
+	http2           bool
+	h2c             bool
 }
 
 // New -.
@@ -27,6 +36,7 @@ func New(handler http.Handler, opts ...Option) *Server {
 		Handler:      handler,
 		ReadTimeout:  _defaultReadTimeout,
 		WriteTimeout: _defaultWriteTimeout,
+		IdleTimeout:  _defaultIdleTimeout,
 		Addr:         _defaultAddr,
 	}
 
@@ -34,6 +44,7 @@ func New(handler http.Handler, opts ...Option) *Server {
 		server:          httpServer,
 		notify:          make(chan error, 1),
 		shutdownTimeout: _defaultShutdownTimeout,
+		http2:           true,
 	}
 
 	// Custom options
@@ -41,6 +52,14 @@ func New(handler http.Handler, opts ...Option) *Server {
 		opt(s)
 	}
 
+	switch {
+	case s.certFile == "" && s.h2c:
+		httpServer.Handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: httpServer.IdleTimeout})
+	case s.certFile != "" && !s.http2:
+		// A non-nil, empty map stops net/http from negotiating h2 over TLS.
+		httpServer.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+	}
+
 	s.start()
 
 	return s
@@ -48,7 +67,11 @@ func New(handler http.Handler, opts ...Option) *Server {
 
 func (s *Server) start() {
 	go func() {
-		s.notify <- s.server.ListenAndServe()
+		if s.certFile != "" {
+			s.notify <- s.server.ListenAndServeTLS(s.certFile, s.keyFile)
+		} else {
+			s.notify <- s.server.ListenAndServe()
+		}
 		close(s.notify)
 	}()
 }
@@ -58,9 +81,10 @@ func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
-// Shutdown -.
-func (s *Server) Shutdown() error {
-	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+// Shutdown waits for in-flight requests until ctx is done or the shutdown
+// timeout passes, whichever comes first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
 	defer cancel()
 
 	return s.server.Shutdown(ctx)
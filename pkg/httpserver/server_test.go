@@ -0,0 +1,125 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+func newServer(t *testing.T, opts ...Option) *Server {
+	t.Helper()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+	s := New(handler, append([]Option{Port("0")}, opts...)...)
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+	return s
+}
+
+func TestOptions(t *testing.T) {
+	s := newServer(t,
+		ReadTimeout(time.Second),
+		ReadHeaderTimeout(2*time.Second),
+		WriteTimeout(3*time.Second),
+		IdleTimeout(4*time.Second),
+		ShutdownTimeout(5*time.Second),
+		MaxHeaderBytes(1024),
+		TLS("cert.pem", "key.pem"),
+	)
+
+	if s.server.Addr != ":0" {
+		t.Errorf("Addr = %q, want :0", s.server.Addr)
+	}
+	for name, got := range map[string][2]time.Duration{
+		"ReadTimeout":       {s.server.ReadTimeout, time.Second},
+		"ReadHeaderTimeout": {s.server.ReadHeaderTimeout, 2 * time.Second},
+		"WriteTimeout":      {s.server.WriteTimeout, 3 * time.Second},
+		"IdleTimeout":       {s.server.IdleTimeout, 4 * time.Second},
+		"shutdownTimeout":   {s.shutdownTimeout, 5 * time.Second},
+	} {
+		if got[0] != got[1] {
+			t.Errorf("%s = %v, want %v", name, got[0], got[1])
+		}
+	}
+	if s.server.MaxHeaderBytes != 1024 {
+		t.Errorf("MaxHeaderBytes = %d, want 1024", s.server.MaxHeaderBytes)
+	}
+	if s.certFile != "cert.pem" || s.keyFile != "key.pem" {
+		t.Errorf("TLS files = %q, %q", s.certFile, s.keyFile)
+	}
+	if !s.http2 || s.h2c {
+		t.Errorf("http2, h2c = %v, %v, want HTTP/2 over TLS only by default", s.http2, s.h2c)
+	}
+}
+
+func TestHTTP2(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		h2c         bool
+		tlsNextNone bool
+	}{
+		{name: "Default"},
+		{name: "H2C", opts: []Option{H2C(true)}, h2c: true},
+		{name: "HTTP2WithoutTLS", opts: []Option{HTTP2(true)}},
+		{name: "TLS", opts: []Option{TLS("cert.pem", "key.pem")}},
+		{name: "TLSIgnoresH2C", opts: []Option{TLS("cert.pem", "key.pem"), H2C(true)}},
+		{name: "TLSWithoutHTTP2", opts: []Option{TLS("cert.pem", "key.pem"), HTTP2(false)}, tlsNextNone: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := newServer(t, tt.opts...)
+
+			if _, plain := s.server.Handler.(http.HandlerFunc); plain == tt.h2c {
+				t.Errorf("handler wrapped for h2c = %v, want %v", !plain, tt.h2c)
+			}
+			if none := s.server.TLSNextProto != nil && len(s.server.TLSNextProto) == 0; none != tt.tlsNextNone {
+				t.Errorf("HTTP/2 over TLS disabled = %v, want %v", none, tt.tlsNextNone)
+			}
+		})
+	}
+}
+
+func TestH2CRequest(t *testing.T) {
+	// Prior knowledge h2c: the client speaks HTTP/2 on a plain connection.
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+
+	for _, enabled := range []bool{true, false} {
+		ts := httptest.NewServer(newServer(t, H2C(enabled)).server.Handler)
+
+		resp, err := client.Get(ts.URL)
+		if !enabled {
+			if err == nil {
+				resp.Body.Close()
+				t.Errorf("h2c request without H2C succeeded, want it refused")
+			}
+			ts.Close()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("h2c request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		ts.Close()
+		if string(body) != "HTTP/2.0" {
+			t.Errorf("protocol = %q, want HTTP/2.0", body)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package jwtauth verifies JSON Web Tokens signed with HS256 or with RS256
+// keys from a local JWKS file.
+package jwtauth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const _defaultRolesClaim = "roles"
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, signed
+// with an unknown key or meant for someone else.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the parts of a verified token the API uses.
+type Claims struct {
+	Subject string
+	Roles   []string
+}
+
+// Verifier -.
+type Verifier struct {
+	secret      []byte
+	jwksFile    string
+	keys        map[string]*rsa.PublicKey
+	issuer      string
+	audience    string
+	rolesClaim  string
+	leeway      time.Duration
+	optionalExp bool
+	parser      *jwt.Parser
+}
+
+// New returns a verifier for the configured keys. At least one of Secret and
+// JWKSFile is required.
+func New(opts ...Option) (*Verifier, error) {
+	v := &Verifier{
+		rolesClaim: _defaultRolesClaim,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	var methods []string
+	if len(v.secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if v.jwksFile != "" {
+		keys, err := loadJWKS(v.jwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("jwtauth - New - loadJWKS: %w", err)
+		}
+		v.keys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, errors.New("jwtauth - New - no secret or JWKS file configured")
+	}
+
+	// exp, nbf and iat are checked with leeway, then iss and aud when set.
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(v.leeway),
+		jwt.WithIssuedAt(),
+	}
+	if !v.optionalExp {
+		parserOpts = append(parserOpts, jwt.WithExpirationRequired())
+	}
+	if v.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(v.audience))
+	}
+	v.parser = jwt.NewParser(parserOpts...)
+
+	return v, nil
+}
+
+// Verify checks the signature and the registered claims of token and returns
+// its subject and roles.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	claims := jwt.MapClaims{}
+
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrInvalidToken, err)
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return Claims{}, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	return Claims{Subject: sub, Roles: roles(claims[v.rolesClaim])}, nil
+}
+
+// key picks the verification key for the token's algorithm and kid header.
+func (v *Verifier) key(t *jwt.Token) (interface{}, error) {
+	switch t.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := t.Header["kid"].(string)
+		if k, ok := v.keys[kid]; ok {
+			return k, nil
+		}
+		// A token without kid is accepted when the set has a single key.
+		if kid == "" && len(v.keys) == 1 {
+			for _, k := range v.keys {
+				return k, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected algorithm %s", t.Method.Alg())
+	}
+}
+
+func roles(claim interface{}) []string {
+	switch r := claim.(type) {
+	case string:
+		return strings.Fields(r)
+	case []interface{}:
+		out := make([]string, 0, len(r))
+		for _, v := range r {
+			if s, ok := v.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// loadJWKS reads the RSA signing keys of a JSON Web Key Set, by kid.
+func loadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jwks
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != "RS256") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: exponent: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s has no RS256 signing keys", path)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,159 @@
+package jwtauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return s
+}
+
+func writeJWKS(t *testing.T, kid string, pub *rsa.PublicKey) string {
+	t.Helper()
+
+	set := map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := New(
+		Secret("s3cret"),
+		JWKSFile(writeJWKS(t, "k1", &rsaKey.PublicKey)),
+		Issuer("https://auth.example.com"),
+		Audience("business-api"),
+		Leeway(30*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	claims := func(extra jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":   "user-1",
+			"iss":   "https://auth.example.com",
+			"aud":   []string{"business-api"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"editor"},
+		}
+		for k, val := range extra {
+			if val == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = val
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  *Claims
+	}{
+		{"HS256", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(nil)), &Claims{"user-1", []string{"editor"}}},
+		{"RS256", sign(t, jwt.SigningMethodRS256, rsaKey, "k1", claims(jwt.MapClaims{"roles": "admin editor"})), &Claims{"user-1", []string{"admin", "editor"}}},
+		{"RS256WithoutKid", sign(t, jwt.SigningMethodRS256, rsaKey, "", claims(nil)), &Claims{"user-1", []string{"editor"}}},
+		{"WrongSecret", sign(t, jwt.SigningMethodHS256, []byte("guess"), "", claims(nil)), nil},
+		{"UnknownRSAKey", sign(t, jwt.SigningMethodRS256, otherKey, "k1", claims(nil)), nil},
+		{"UnexpectedAlgorithm", sign(t, jwt.SigningMethodHS512, []byte("s3cret"), "", claims(nil)), nil},
+		{"None", sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims(nil)), nil},
+		{"Expired", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})), nil},
+		{"NoExpiry", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"exp": nil})), nil},
+		{"ExpiredWithinLeeway", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"exp": time.Now().Add(-10 * time.Second).Unix()})), &Claims{"user-1", []string{"editor"}}},
+		{"NotValidYet", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"nbf": time.Now().Add(time.Hour).Unix()})), nil},
+		{"IssuedInFuture", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"iat": time.Now().Add(time.Hour).Unix()})), nil},
+		{"WrongIssuer", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"iss": "https://evil.example.com"})), nil},
+		{"WrongAudience", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"aud": "other"})), nil},
+		{"NoSubject", sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims(jwt.MapClaims{"sub": ""})), nil},
+		{"Malformed", "not.a.token", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if tt.want == nil {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Verify = %+v, %v, want ErrInvalidToken", got, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if !reflect.DeepEqual(got, *tt.want) {
+				t.Errorf("Verify = %+v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowMissingExpiry(t *testing.T) {
+	token := sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", jwt.MapClaims{"sub": "user-1"})
+
+	v, err := New(Secret("s3cret"), AllowMissingExpiry(true))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if got, err := v.Verify(token); err != nil || got.Subject != "user-1" {
+		t.Errorf("Verify without exp = %+v, %v, want it accepted", got, err)
+	}
+
+	expired := sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(-time.Minute).Unix()})
+	if _, err := v.Verify(expired); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify of an expired token = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestNewRequiresKeys(t *testing.T) {
+	if _, err := New(); err == nil {
+		t.Error("New without keys succeeded")
+	}
+}
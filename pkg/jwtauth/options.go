@@ -0,0 +1,58 @@
+package jwtauth
+
+import "time"
+
+// Option -.
+type Option func(*Verifier)
+
+// Secret accepts HS256 tokens signed with secret.
+func Secret(secret string) Option {
+	return func(v *Verifier) {
+		v.secret = []byte(secret)
+	}
+}
+
+// JWKSFile accepts RS256 tokens signed by the RSA keys of the JSON Web Key Set
+// at path.
+func JWKSFile(path string) Option {
+	return func(v *Verifier) {
+		v.jwksFile = path
+	}
+}
+
+// Issuer requires the iss claim to equal issuer.
+func Issuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// Audience requires the aud claim to contain audience.
+func Audience(audience string) Option {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// RolesClaim names the claim holding the roles, as an array or a
+// space-separated string. Defaults to "roles".
+func RolesClaim(name string) Option {
+	return func(v *Verifier) {
+		v.rolesClaim = name
+	}
+}
+
+// AllowMissingExpiry accepts tokens without an exp claim. Such tokens never
+// expire, so only enable it for issuers that cannot set one.
+func AllowMissingExpiry(allow bool) Option {
+	return func(v *Verifier) {
+		v.optionalExp = allow
+	}
+}
+
+// Leeway tolerates clock skew when checking exp, nbf and iat.
+func Leeway(d time.Duration) Option {
+	return func(v *Verifier) {
+		v.leeway = d
+	}
+}
@@ -0,0 +1,33 @@
+package logger
+
+import "context"
+
+// Field names of the request-scoped logger.
+const (
+	FieldRequestID = "request_id"
+	FieldRoute     = "route"
+	FieldUser      = "user"
+)
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying l.
+func WithContext(ctx context.Context, l Interface) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx by WithContext, or fallback if
+// there is none.
+func FromContext(ctx context.Context, fallback Interface) Interface {
+	if l, ok := ctx.Value(ctxKey{}).(Interface); ok {
+		return l
+	}
+
+	return fallback
+}
+
+// AddFields returns a copy of ctx whose logger also carries fields. The
+// logger is taken from ctx, or fallback if there is none.
+func AddFields(ctx context.Context, fallback Interface, fields Fields) context.Context {
+	return WithContext(ctx, FromContext(ctx, fallback).With(fields))
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -15,17 +16,33 @@ type Interface interface {
 	Warn(message string, args ...interface{})
 	Error(message interface{}, args ...interface{})
 	Fatal(message interface{}, args ...interface{})
+
+	// With returns a child logger that adds fields to every entry.
+	With(fields Fields) Interface
 }
 
+// Fields are key/value pairs attached to log entries.
+type Fields map[string]interface{}
+
 // Logger -.
 type Logger struct {
-	logger *zerolog.Logger
+	logger zerolog.Logger
 }
 
 var _ Interface = (*Logger)(nil)
 
 // New -.
 func New(level string) *Logger {
+	return newLogger(os.Stdout, level)
+}
+
+// NewWithWriter is New writing to w instead of stdout, for programs whose
+// stdout carries their output.
+func NewWithWriter(w io.Writer, level string) *Logger {
+	return newLogger(w, level)
+}
+
+func newLogger(w io.Writer, level string) *Logger {
 	var l zerolog.Level
 
 	switch strings.ToLower(level) {
@@ -41,62 +58,72 @@ func New(level string) *Logger {
 		l = zerolog.InfoLevel
 	}
 
-	zerolog.SetGlobalLevel(l)
-
-	skipFrameCount := 3
-	logger := zerolog.New(os.Stdout).With().Timestamp().CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).Logger()
+	// Each entry goes through one exported method and write before reaching
+	// zerolog, so the caller is two frames further up.
+	skipFrameCount := 2
+	logger := zerolog.New(w).Level(l).With().Timestamp().CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + skipFrameCount).Logger()
 
 	return &Logger{
-		logger: &logger,
+		logger: logger,
 	}
 }
 
 // Debug -.
 func (l *Logger) Debug(message interface{}, args ...interface{}) {
-	l.msg("debug", message, args...)
+	l.write(zerolog.DebugLevel, message, args...)
 }
 
 // Info -.
 func (l *Logger) Info(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.write(zerolog.InfoLevel, message, args...)
 }
 
 // Warn -.
 func (l *Logger) Warn(message string, args ...interface{}) {
-	l.log(message, args...)
+	l.write(zerolog.WarnLevel, message, args...)
 }
 
 // Error -.
 func (l *Logger) Error(message interface{}, args ...interface{}) {
-	if l.logger.GetLevel() == zerolog.DebugLevel {
-		l.Debug(message, args...)
-	}
-
-	l.msg("error", message, args...)
+	l.write(zerolog.ErrorLevel, message, args...)
 }
 
 // Fatal -.
 func (l *Logger) Fatal(message interface{}, args ...interface{}) {
-	l.msg("fatal", message, args...)
+	l.write(zerolog.FatalLevel, message, args...)
 
 	os.Exit(1)
 }
 
-func (l *Logger) log(message string, args ...interface{}) {
-	if len(args) == 0 {
-		l.logger.Info().Msg(message)
-	} else {
-		l.logger.Info().Msgf(message, args...)
+// With -.
+func (l *Logger) With(fields Fields) Interface {
+	return &Logger{
+		logger: l.logger.With().Fields(map[string]interface{}(fields)).Logger(),
 	}
 }
 
-func (l *Logger) msg(level string, message interface{}, args ...interface{}) {
-	switch msg := message.(type) {
+// write logs message at level. message is a string, optionally a format for
+// args, or an error.
+func (l *Logger) write(level zerolog.Level, message interface{}, args ...interface{}) {
+	// WithLevel, unlike Fatal(), leaves exiting to the caller.
+	e := l.logger.WithLevel(level)
+	if e == nil {
+		return
+	}
+
+	var msg string
+	switch m := message.(type) {
 	case error:
-		l.log(msg.Error(), args...)
+		msg = m.Error()
 	case string:
-		l.log(msg, args...)
+		msg = m
 	default:
-		l.log(fmt.Sprintf("%s message %v has unknown type %v", level, message, msg), args...)
+		msg = fmt.Sprintf("%s message %v has unknown type %T", level, message, message)
+	}
+
+	if len(args) == 0 {
+		e.Msg(msg)
+	} else {
+		e.Msgf(msg, args...)
 	}
 }
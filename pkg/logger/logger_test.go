@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func entries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(&buf, "debug")
+
+	l.Debug("d")
+	l.Info("i %d", 1)
+	l.Warn("w")
+	l.Error(errors.New("e"))
+
+	got := entries(t, &buf)
+	want := []struct{ level, msg string }{{"debug", "d"}, {"info", "i 1"}, {"warn", "w"}, {"error", "e"}}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %s", len(got), len(want), buf.String())
+	}
+	for i, w := range want {
+		if got[i]["level"] != w.level || got[i]["message"] != w.msg {
+			t.Errorf("entry %d = %v, want level %s message %s", i, got[i], w.level, w.msg)
+		}
+		if caller, _ := got[i]["caller"].(string); !strings.Contains(caller, "logger_test.go") {
+			t.Errorf("entry %d caller = %q, want logger_test.go", i, caller)
+		}
+	}
+}
+
+func TestLevelFilter(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(&buf, "warn")
+
+	l.Debug("d")
+	l.Info("i")
+	l.Warn("w")
+
+	if got := entries(t, &buf); len(got) != 1 || got[0]["level"] != "warn" {
+		t.Fatalf("got %s, want only the warn entry", buf.String())
+	}
+}
+
+func TestContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(&buf, "info")
+
+	ctx := AddFields(context.Background(), l, Fields{FieldRoute: "/v1/business/:id"})
+	ctx = AddFields(ctx, l, Fields{FieldRequestID: "abc"})
+	FromContext(ctx, nil).Info("hello")
+
+	got := entries(t, &buf)
+	if len(got) != 1 || got[0][FieldRoute] != "/v1/business/:id" || got[0][FieldRequestID] != "abc" {
+		t.Fatalf("got %s, want route and request_id fields", buf.String())
+	}
+
+	if FromContext(context.Background(), l) != l {
+		t.Error("FromContext without a logger should return the fallback")
+	}
+}
@@ -0,0 +1,45 @@
+// Package ratelimit implements token-bucket rate limiting over a pluggable
+// store, so several instances can share their buckets.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit allows Requests requests per Per, in bursts of up to Requests. The
+// bucket refills continuously, one token every Per/Requests.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Enabled reports whether l limits anything; the zero Limit does not.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Per > 0
+}
+
+// rate returns the refill rate in tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	Allowed bool
+	// Limit is the bucket's capacity.
+	Limit int
+	// Remaining is how many requests the bucket allows right now.
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed; zero when
+	// this one was.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. Take removes one token from the bucket of key,
+// creating a full one on first use, and must be safe for concurrent use.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,120 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func newStore() (*MemoryStore, *clock) {
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = c.Now
+	return s, c
+}
+
+func TestMemoryStoreBurstAndRefill(t *testing.T) {
+	s, c := newStore()
+	limit := Limit{Requests: 3, Per: 3 * time.Second}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		res, err := s.Take(ctx, "k", limit)
+		if err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+		if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+			t.Fatalf("Take = %+v, want allowed with %d remaining", res, i)
+		}
+	}
+
+	res, _ := s.Take(ctx, "k", limit)
+	if res.Allowed || res.RetryAfter != time.Second || res.Reset != 3*time.Second {
+		t.Fatalf("Take over the limit = %+v, want denied, retry after 1s, reset in 3s", res)
+	}
+
+	if res, _ := s.Take(ctx, "other", limit); !res.Allowed {
+		t.Errorf("Take on another key = %+v, want allowed", res)
+	}
+
+	c.Advance(time.Second)
+	if res, _ := s.Take(ctx, "k", limit); !res.Allowed || res.Remaining != 0 {
+		t.Errorf("Take after one refill = %+v, want allowed with 0 remaining", res)
+	}
+
+	c.Advance(time.Hour)
+	if res, _ := s.Take(ctx, "k", limit); !res.Allowed || res.Remaining != 2 {
+		t.Errorf("Take after a long pause = %+v, want a full bucket minus one", res)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	s, c := newStore()
+	limit := Limit{Requests: 10, Per: time.Second}
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if _, err := s.Take(ctx, key, limit); err != nil {
+			t.Fatalf("Take: %v", err)
+		}
+	}
+
+	c.Advance(2 * _sweepInterval)
+	if _, err := s.Take(ctx, "d", limit); err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+
+	if len(s.buckets) != 1 {
+		t.Errorf("buckets after sweep = %d, want only the new one", len(s.buckets))
+	}
+}
+
+func TestMemoryStoreConcurrent(t *testing.T) {
+	s, _ := newStore()
+	limit := Limit{Requests: 50, Per: time.Hour}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := s.Take(context.Background(), "k", limit)
+			if err != nil {
+				t.Errorf("Take: %v", err)
+				return
+			}
+			if res.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 50 {
+		t.Errorf("allowed = %d, want 50", allowed)
+	}
+}
@@ -0,0 +1,41 @@
+package tracing
+
+// Option -.
+type Option func(*Provider)
+
+// Exporter is one of ExporterNone, ExporterStdout or ExporterOTLP.
+func Exporter(name string) Option {
+	return func(p *Provider) {
+		p.exporter = name
+	}
+}
+
+// Endpoint is the host:port of the OTLP/HTTP collector.
+func Endpoint(endpoint string) Option {
+	return func(p *Provider) {
+		p.endpoint = endpoint
+	}
+}
+
+// Insecure sends OTLP over plain HTTP.
+func Insecure(insecure bool) Option {
+	return func(p *Provider) {
+		p.insecure = insecure
+	}
+}
+
+// SampleRatio is the fraction of new traces recorded; traces started upstream
+// follow the caller's sampling decision.
+func SampleRatio(ratio float64) Option {
+	return func(p *Provider) {
+		p.sampleRatio = ratio
+	}
+}
+
+// Service names the application in exported spans.
+func Service(name, version string) Option {
+	return func(p *Provider) {
+		p.serviceName = name
+		p.version = version
+	}
+}
@@ -0,0 +1,108 @@
+// Package tracing configures the OpenTelemetry tracer provider and W3C trace
+// context propagation.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Exporters -.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+const (
+	_defaultExporter    = ExporterNone
+	_defaultSampleRatio = 1.0
+)
+
+// Provider -.
+type Provider struct {
+	exporter    string
+	endpoint    string
+	insecure    bool
+	sampleRatio float64
+	serviceName string
+	version     string
+
+	tp *sdktrace.TracerProvider
+}
+
+// New installs a global tracer provider exporting to the configured exporter.
+// Incoming and outgoing trace context always uses the W3C traceparent and
+// baggage headers, even when spans are not exported.
+func New(ctx context.Context, opts ...Option) (*Provider, error) {
+	p := &Provider{
+		exporter:    _defaultExporter,
+		sampleRatio: _defaultSampleRatio,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch p.exporter {
+	case ExporterNone:
+		return p, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(p.endpoint)}
+		if p.insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("tracing - New - unknown exporter %q", p.exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing - New - %s exporter: %w", p.exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(p.serviceName),
+		semconv.ServiceVersion(p.version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing - New - resource.Merge: %w", err)
+	}
+
+	p.tp = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(p.sampleRatio))),
+	)
+	otel.SetTracerProvider(p.tp)
+
+	return p, nil
+}
+
+// Shutdown flushes buffered spans.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.tp == nil {
+		return nil
+	}
+	return p.tp.Shutdown(ctx)
+}